@@ -8,6 +8,7 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // часовые пояса пользователей не должны зависеть от системной базы
 
 	"ivanSaichkin/language-bot/internal/bot"
 	"ivanSaichkin/language-bot/internal/config"
@@ -59,7 +60,13 @@ func main() {
 		telegramBot,
 		serviceContainer.UserService,
 		serviceContainer.WordService,
+		serviceContainer.DeckService,
+		serviceContainer.ImportService,
+		serviceContainer.ExportService,
 		serviceContainer.ReviewService,
+		serviceContainer.QuizService,
+		serviceContainer.SprintService,
+		serviceContainer.MatchService,
 		serviceContainer.StatsService,
 		serviceContainer.SessionService,
 		serviceContainer.RepetitionService,
@@ -103,9 +110,12 @@ func initializeServices(db *sql.DB) *service.ServiceContainer {
 	wordRepo := repository.NewWordRepository(db)
 	statsRepo := repository.NewStatsRepository(db)
 	sessionRepo := repository.NewSessionRepository(db)
+	reviewLogRepo := repository.NewReviewLogRepository(db)
+	cardRepo := repository.NewCardRepository(db)
+	deckRepo := repository.NewDeckRepository(db)
 
 	log.Println("🔨 Creating services...")
-	return service.NewServiceContainer(userRepo, wordRepo, statsRepo, sessionRepo)
+	return service.NewServiceContainer(userRepo, wordRepo, statsRepo, sessionRepo, reviewLogRepo, cardRepo, deckRepo)
 }
 
 func runBot(ctx context.Context, botAPI *tgbotapi.BotAPI, handler *bot.SimpleHandler, services *service.ServiceContainer) {
@@ -7,4 +7,5 @@ require github.com/joho/godotenv v1.5.1
 require (
 	github.com/go-telegram-bot-api/telegram-bot-api/v5 v5.5.1
 	github.com/mattn/go-sqlite3 v1.14.32
+	golang.org/x/text v0.21.0
 )
@@ -0,0 +1,115 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	"ivanSaichkin/language-bot/internal/domain"
+	"ivanSaichkin/language-bot/internal/service"
+)
+
+// Сколько слов каждой группы перечислять в итоге, чтобы сообщение не упёрлось в лимит Telegram
+const bulkSummaryLimit = 20
+
+// handleBulkWordAddition добавляет слова из многострочного сообщения: строка - «слово - перевод | пример»
+func (h *SimpleHandler) handleBulkWordAddition(ctx context.Context, chatID int64, text string) {
+	user, err := h.userService.GetUser(ctx, chatID)
+	if err != nil {
+		h.sendMessage(chatID, "❌ Не удалось получить информацию о пользователе")
+		return
+	}
+
+	var words []*domain.Word
+	var badLines []int
+	lineNumbers := make(map[*domain.Word]int)
+
+	for i, line := range strings.Split(text, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		original, translations, example, ok := parseWordInput(line)
+		if !ok {
+			badLines = append(badLines, i+1)
+			continue
+		}
+
+		word := newWordFromInput(chatID, user.StudyLanguage, original, translations, example)
+		lineNumbers[word] = i + 1
+		words = append(words, word)
+	}
+
+	if len(words) > service.MaxBulkWords {
+		h.sendMessage(chatID, fmt.Sprintf("❌ За один раз можно добавить не больше %d слов. Разбейте список на части.", service.MaxBulkWords))
+		return
+	}
+
+	result := &service.BulkAddResult{}
+	if len(words) > 0 {
+		if result, err = h.wordService.AddWords(ctx, chatID, words, service.DuplicateSkip); err != nil {
+			log.Printf("❌ Error adding words: %v", err)
+			h.sendMessage(chatID, "❌ Не удалось добавить слова, ни одно слово не сохранено")
+			return
+		}
+	}
+
+	// Строки, которые разобрались, но не прошли проверку, тоже считаем ошибочными
+	for _, word := range result.Invalid {
+		badLines = append(badLines, lineNumbers[word])
+	}
+
+	if len(result.Added) > 0 {
+		if err := h.userService.SetUserState(ctx, chatID, ""); err != nil {
+			log.Printf("⚠️ Failed to reset user state: %v", err)
+		}
+	}
+
+	if len(result.Duplicates) == 0 {
+		h.sendMessage(chatID, bulkAddSummary(result, badLines))
+		return
+	}
+
+	h.stateMu.Lock()
+	h.duplicates[chatID] = &pendingDuplicates{words: result.Duplicates}
+	h.stateMu.Unlock()
+	h.sendMessageWithKeyboard(chatID, bulkAddSummary(result, badLines), duplicateKeyboard())
+}
+
+func bulkAddSummary(result *service.BulkAddResult, badLines []int) string {
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("📥 *Добавлено слов: %d*\n", len(result.Added)))
+	writeWordList(&response, result.Added, "✅")
+
+	if len(result.Duplicates) > 0 {
+		response.WriteString(fmt.Sprintf("\n♻️ *Уже есть в словаре или очень похожи, пропущено: %d*\n", len(result.Duplicates)))
+		writeWordList(&response, result.Duplicates, "•")
+		response.WriteString("Их переводы можно добавить к словам словаря или сохранить слова отдельно.\n")
+	}
+
+	if len(badLines) > 0 {
+		sort.Ints(badLines)
+		lines := make([]string, len(badLines))
+		for i, line := range badLines {
+			lines[i] = strconv.Itoa(line)
+		}
+
+		response.WriteString(fmt.Sprintf("\n❌ *Не удалось разобрать строки:* %s\n", strings.Join(lines, ", ")))
+		response.WriteString("Формат строки: слово - перевод1, перевод2 | пример\n")
+	}
+
+	return response.String()
+}
+
+func writeWordList(response *strings.Builder, words []*domain.Word, mark string) {
+	for i, word := range words {
+		if i >= bulkSummaryLimit {
+			response.WriteString(fmt.Sprintf("... и ещё %d\n", len(words)-i))
+			break
+		}
+		response.WriteString(fmt.Sprintf("%s *%s* - %s\n", mark, word.Original, word.DisplayTranslation()))
+	}
+}
@@ -0,0 +1,219 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Данные кнопки имеют вид "v1:действие:арг1:арг2". Telegram ограничивает их 64 байтами,
+// поэтому названия действий короткие, а в аргументах передаются только идентификаторы.
+const (
+	callbackVersion       = "v1"
+	callbackSeparator     = ":"
+	maxCallbackDataLength = 64
+)
+
+const (
+	callbackNoop        = "noop"
+	callbackReveal      = "rv"
+	callbackGrade       = "gr"
+	callbackChoice      = "mc"
+	callbackGoal        = "goal"
+	callbackReview      = "rev"
+	callbackStopReview  = "stop"
+	callbackAddWord     = "add"
+	callbackQuizChoice  = "qz"
+	callbackQuizReset   = "qzr"
+	callbackSprint      = "sp"
+	callbackMatch       = "mt"
+	callbackDeck        = "dk"
+	callbackLanguage    = "lang"
+	callbackImport      = "imp"
+	callbackExport      = "exp"
+	callbackWordPick    = "wp"
+	callbackWordPage    = "wpg"
+	callbackEditField   = "ef"
+	callbackEditValue   = "ev"
+	callbackEditConfirm = "ec"
+	callbackDeleteWord  = "del"
+	callbackWordList    = "wl"
+	callbackWordCard    = "wc"
+	callbackResetWord   = "rp"
+	callbackDuplicate   = "dup"
+)
+
+// Кнопки старого формата без версии, оставшиеся в истории чатов
+var legacyCallbackActions = map[string]string{
+	"reveal": callbackReveal,
+	"grade":  callbackGrade,
+	"mc":     callbackChoice,
+}
+
+type callbackData struct {
+	Action string
+	Args   []string
+}
+
+type callbackHandlerFunc func(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData)
+
+func encodeCallback(action string, args ...any) (string, error) {
+	parts := []string{callbackVersion, action}
+	for _, arg := range args {
+		value := fmt.Sprint(arg)
+		if strings.Contains(value, callbackSeparator) {
+			return "", fmt.Errorf("callback argument %q contains separator", value)
+		}
+		parts = append(parts, value)
+	}
+
+	data := strings.Join(parts, callbackSeparator)
+	if len(data) > maxCallbackDataLength {
+		return "", fmt.Errorf("callback data too long: %d bytes", len(data))
+	}
+
+	return data, nil
+}
+
+func decodeCallback(data string) (callbackData, error) {
+	parts := strings.Split(data, callbackSeparator)
+
+	if parts[0] == callbackVersion {
+		if len(parts) < 2 || parts[1] == "" {
+			return callbackData{}, fmt.Errorf("callback without action: %q", data)
+		}
+
+		return callbackData{Action: parts[1], Args: parts[2:]}, nil
+	}
+
+	if action, ok := legacyCallbackActions[parts[0]]; ok {
+		return callbackData{Action: action, Args: parts[1:]}, nil
+	}
+
+	return callbackData{}, fmt.Errorf("unsupported callback data: %q", data)
+}
+
+// Int возвращает числовой аргумент с указанным номером
+func (d callbackData) Int(index int) (int, error) {
+	if index >= len(d.Args) {
+		return 0, fmt.Errorf("callback %s has no argument %d", d.Action, index)
+	}
+
+	return strconv.Atoi(d.Args[index])
+}
+
+func (d callbackData) Arg(index int) string {
+	if index >= len(d.Args) {
+		return ""
+	}
+
+	return d.Args[index]
+}
+
+// callbackButton создаёт кнопку; если данные не помещаются в лимит, кнопка ничего не делает
+func callbackButton(text, action string, args ...any) tgbotapi.InlineKeyboardButton {
+	data, err := encodeCallback(action, args...)
+	if err != nil {
+		log.Printf("⚠️ Failed to encode callback %s: %v", action, err)
+		data, _ = encodeCallback(callbackNoop)
+	}
+
+	return tgbotapi.NewInlineKeyboardButtonData(text, data)
+}
+
+func (h *SimpleHandler) registerCallbacks() {
+	h.callbacks = map[string]callbackHandlerFunc{
+		callbackNoop:        h.handleNoopCallback,
+		callbackReveal:      h.handleRevealCallback,
+		callbackGrade:       h.handleGradeCallback,
+		callbackChoice:      h.handleChoiceCallback,
+		callbackGoal:        h.handleGoalCallback,
+		callbackReview:      h.handleReviewCallback,
+		callbackStopReview:  h.handleStopReviewCallback,
+		callbackAddWord:     h.handleAddWordCallback,
+		callbackQuizChoice:  h.handleQuizChoiceCallback,
+		callbackQuizReset:   h.handleQuizResetCallback,
+		callbackSprint:      h.handleSprintCallback,
+		callbackMatch:       h.handleMatchCallback,
+		callbackDeck:        h.handleDeckCallback,
+		callbackLanguage:    h.handleLanguageCallback,
+		callbackImport:      h.handleImportCallback,
+		callbackExport:      h.handleExportCallback,
+		callbackWordPick:    h.handleWordPickCallback,
+		callbackWordPage:    h.handleWordPageCallback,
+		callbackEditField:   h.handleEditFieldCallback,
+		callbackEditValue:   h.handleEditValueCallback,
+		callbackEditConfirm: h.handleEditConfirmCallback,
+		callbackDeleteWord:  h.handleDeleteWordCallback,
+		callbackWordList:    h.handleWordListCallback,
+		callbackWordCard:    h.handleWordCardCallback,
+		callbackResetWord:   h.handleResetProgressCallback,
+		callbackDuplicate:   h.handleDuplicateCallback,
+	}
+}
+
+// handleCallbackQuery разбирает данные кнопки и передаёт их обработчику действия.
+// Обработчик обязан ответить на callback, иначе у пользователя будет крутиться индикатор загрузки.
+func (h *SimpleHandler) handleCallbackQuery(ctx context.Context, query *tgbotapi.CallbackQuery) {
+	if query.Message == nil {
+		h.answerCallback(query.ID, "")
+		return
+	}
+
+	chatID := query.Message.Chat.ID
+	if _, exists := h.reviewSession(chatID); !exists {
+		h.loadUserSessions(ctx, chatID)
+	}
+
+	log.Printf("🔘 Callback from user %d: %s", chatID, query.Data)
+
+	data, err := decodeCallback(query.Data)
+	if err != nil {
+		log.Printf("⚠️ %v", err)
+		h.answerCallback(query.ID, "❌ Кнопка устарела")
+		return
+	}
+
+	handler, ok := h.callbacks[data.Action]
+	if !ok {
+		h.answerCallback(query.ID, "❌ Неизвестное действие")
+		return
+	}
+
+	handler(ctx, query, data)
+}
+
+func (h *SimpleHandler) handleNoopCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	h.answerCallback(query.ID, "")
+}
+
+func (h *SimpleHandler) answerCallback(callbackID, text string) {
+	if _, err := h.bot.Request(tgbotapi.NewCallback(callbackID, text)); err != nil {
+		log.Printf("❌ Error answering callback: %v", err)
+	}
+}
+
+func (h *SimpleHandler) editMessage(chatID int64, messageID int, text string, keyboard *tgbotapi.InlineKeyboardMarkup) {
+	edit := tgbotapi.NewEditMessageText(chatID, messageID, text)
+	edit.ParseMode = "Markdown"
+	edit.ReplyMarkup = keyboard
+
+	if _, err := h.bot.Send(edit); err != nil {
+		log.Printf("❌ Error editing message: %v", err)
+	}
+}
+
+// removeKeyboard убирает кнопки у сообщения, чтобы их нельзя было нажать повторно
+func (h *SimpleHandler) removeKeyboard(chatID int64, messageID int) {
+	edit := tgbotapi.NewEditMessageReplyMarkup(chatID, messageID, tgbotapi.InlineKeyboardMarkup{
+		InlineKeyboard: [][]tgbotapi.InlineKeyboardButton{},
+	})
+
+	if _, err := h.bot.Send(edit); err != nil {
+		log.Printf("❌ Error removing keyboard: %v", err)
+	}
+}
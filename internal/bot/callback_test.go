@@ -0,0 +1,129 @@
+package bot
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEncodeCallback(t *testing.T) {
+	// "v1:wp:" занимает 6 байт, остальное - аргумент
+	fits := strings.Repeat("a", maxCallbackDataLength-6)
+
+	tests := []struct {
+		name    string
+		action  string
+		args    []any
+		want    string
+		wantErr bool
+	}{
+		{"no args", callbackNoop, nil, "v1:noop", false},
+		{"int and string args", callbackGrade, []any{42, "ok"}, "v1:gr:42:ok", false},
+		{"negative int", callbackDeck, []any{-1}, "v1:dk:-1", false},
+		{"exactly 64 bytes", callbackWordPick, []any{fits}, "v1:wp:" + fits, false},
+		{"65 bytes", callbackWordPick, []any{fits + "a"}, "", true},
+		{"multibyte args counted in bytes", callbackWordPick, []any{strings.Repeat("я", 30)}, "", true},
+		{"separator in arg", callbackEditValue, []any{"a:b"}, "", true},
+		{"separator as arg", callbackEditValue, []any{1, callbackSeparator}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := encodeCallback(tt.action, tt.args...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("encodeCallback(%q, %v) error = %v, wantErr %v", tt.action, tt.args, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("encodeCallback(%q, %v) = %q, want %q", tt.action, tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeCallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    callbackData
+		wantErr bool
+	}{
+		{"no args", "v1:noop", callbackData{Action: callbackNoop, Args: []string{}}, false},
+		{"with args", "v1:gr:42:ok", callbackData{Action: callbackGrade, Args: []string{"42", "ok"}}, false},
+		{"empty arg kept", "v1:ev:1:", callbackData{Action: callbackEditValue, Args: []string{"1", ""}}, false},
+		{"legacy reveal", "reveal:7", callbackData{Action: callbackReveal, Args: []string{"7"}}, false},
+		{"legacy grade", "grade:7:3", callbackData{Action: callbackGrade, Args: []string{"7", "3"}}, false},
+		{"legacy choice", "mc:7:2", callbackData{Action: callbackChoice, Args: []string{"7", "2"}}, false},
+		{"version only", "v1", callbackData{}, true},
+		{"empty action", "v1::1", callbackData{}, true},
+		{"unknown version", "v2:gr:1", callbackData{}, true},
+		{"unknown legacy action", "delete:1", callbackData{}, true},
+		{"empty data", "", callbackData{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeCallback(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeCallback(%q) error = %v, wantErr %v", tt.data, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeCallback(%q) = %+v, want %+v", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCallbackRoundTrip(t *testing.T) {
+	encoded, err := encodeCallback(callbackSprint, 3, 1)
+	if err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+
+	data, err := decodeCallback(encoded)
+	if err != nil {
+		t.Fatalf("decode %q: %v", encoded, err)
+	}
+	if data.Action != callbackSprint || data.Arg(0) != "3" || data.Arg(1) != "1" {
+		t.Errorf("got %+v, want action %q with args 3, 1", data, callbackSprint)
+	}
+}
+
+func TestCallbackDataArgs(t *testing.T) {
+	data := callbackData{Action: callbackGrade, Args: []string{"42", "ok"}}
+
+	tests := []struct {
+		name    string
+		index   int
+		wantInt int
+		wantErr bool
+		wantArg string
+	}{
+		{"numeric arg", 0, 42, false, "42"},
+		{"non-numeric arg", 1, 0, true, "ok"},
+		{"missing arg", 2, 0, true, ""},
+		{"far missing arg", 10, 0, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := data.Int(tt.index)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Int(%d) error = %v, wantErr %v", tt.index, err, tt.wantErr)
+			}
+			if got != tt.wantInt {
+				t.Errorf("Int(%d) = %d, want %d", tt.index, got, tt.wantInt)
+			}
+			if arg := data.Arg(tt.index); arg != tt.wantArg {
+				t.Errorf("Arg(%d) = %q, want %q", tt.index, arg, tt.wantArg)
+			}
+		})
+	}
+
+	empty := callbackData{Action: callbackNoop}
+	if _, err := empty.Int(0); err == nil {
+		t.Error("Int(0) without args: want error")
+	}
+	if arg := empty.Arg(0); arg != "" {
+		t.Errorf("Arg(0) without args = %q, want empty", arg)
+	}
+}
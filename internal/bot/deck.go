@@ -0,0 +1,290 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"ivanSaichkin/language-bot/internal/constants"
+	"ivanSaichkin/language-bot/internal/domain"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const deckCommandsHelp = `• /deck new название - создать колоду
+• /deck rename старое | новое - переименовать
+• /deck delete название - удалить (слова останутся без колоды)
+• /deck use название - добавлять новые слова в колоду
+• /deck none - добавлять новые слова без колоды
+• /move слово | колода - перенести слово
+• /review колода, /words колода - повторять и смотреть слова колоды`
+
+// handleDeckCommand: /deck [new|rename|delete|use|none] [название]
+func (h *SimpleHandler) handleDeckCommand(ctx context.Context, chatID int64, args string) {
+	action, name, _ := strings.Cut(strings.TrimSpace(args), " ")
+	name = strings.TrimSpace(name)
+
+	switch strings.ToLower(action) {
+	case "":
+		text, keyboard, err := h.deckList(ctx, chatID)
+		if err != nil {
+			log.Printf("❌ Error loading decks: %v", err)
+			h.sendMessage(chatID, "❌ Не удалось загрузить колоды")
+			return
+		}
+		h.sendMessageWithKeyboard(chatID, text, keyboard)
+
+	case "new", "create", "создать":
+		h.createDeck(ctx, chatID, name)
+
+	case "rename", "переименовать":
+		oldName, newName, ok := strings.Cut(name, "|")
+		if !ok || strings.TrimSpace(oldName) == "" || strings.TrimSpace(newName) == "" {
+			h.sendMessage(chatID, "❌ Формат: /deck rename старое название | новое название")
+			return
+		}
+		h.renameDeck(ctx, chatID, strings.TrimSpace(oldName), strings.TrimSpace(newName))
+
+	case "delete", "удалить":
+		deck := h.findDeckOrReport(ctx, chatID, name)
+		if deck == nil {
+			return
+		}
+
+		if err := h.deckService.DeleteDeck(ctx, deck); err != nil {
+			log.Printf("❌ Error deleting deck: %v", err)
+			h.sendMessage(chatID, "❌ Не удалось удалить колоду")
+			return
+		}
+		h.sendMessage(chatID, fmt.Sprintf("🗑 Колода «%s» удалена. Её слова остались в вашем словаре без колоды.", deck.Name))
+
+	case "use", "выбрать":
+		deck := h.findDeckOrReport(ctx, chatID, name)
+		if deck == nil {
+			return
+		}
+		h.setCurrentDeck(ctx, chatID, deck)
+
+	case "none", "без":
+		h.setCurrentDeck(ctx, chatID, nil)
+
+	default:
+		h.sendMessage(chatID, "❌ Неизвестное действие.\n\n"+deckCommandsHelp)
+	}
+}
+
+func (h *SimpleHandler) createDeck(ctx context.Context, chatID int64, name string) {
+	if name == "" {
+		h.sendMessage(chatID, "❌ Укажите название: /deck new Путешествия")
+		return
+	}
+
+	if len([]rune(name)) > domain.MaxDeckNameLength {
+		h.sendMessage(chatID, fmt.Sprintf("❌ Название колоды должно быть не длиннее %d символов", domain.MaxDeckNameLength))
+		return
+	}
+
+	if existing, err := h.deckService.FindDeck(ctx, chatID, name); err == nil && existing != nil {
+		h.sendMessage(chatID, fmt.Sprintf("❌ Колода «%s» уже есть", existing.Name))
+		return
+	}
+
+	deck, err := h.deckService.CreateDeck(ctx, chatID, name)
+	if err != nil {
+		log.Printf("❌ Error creating deck: %v", err)
+		h.sendMessage(chatID, "❌ Не удалось создать колоду")
+		return
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(callbackButton("📥 Добавлять слова сюда", callbackDeck, deck.ID)),
+	)
+	h.sendMessageWithKeyboard(chatID, fmt.Sprintf("📂 Колода «%s» создана", deck.Name), keyboard)
+}
+
+func (h *SimpleHandler) renameDeck(ctx context.Context, chatID int64, oldName, newName string) {
+	deck := h.findDeckOrReport(ctx, chatID, oldName)
+	if deck == nil {
+		return
+	}
+
+	if len([]rune(newName)) > domain.MaxDeckNameLength {
+		h.sendMessage(chatID, fmt.Sprintf("❌ Название колоды должно быть не длиннее %d символов", domain.MaxDeckNameLength))
+		return
+	}
+
+	if existing, err := h.deckService.FindDeck(ctx, chatID, newName); err == nil && existing != nil && existing.ID != deck.ID {
+		h.sendMessage(chatID, fmt.Sprintf("❌ Колода «%s» уже есть", existing.Name))
+		return
+	}
+
+	previous := deck.Name
+	if err := h.deckService.RenameDeck(ctx, deck, newName); err != nil {
+		log.Printf("❌ Error renaming deck: %v", err)
+		h.sendMessage(chatID, "❌ Не удалось переименовать колоду")
+		return
+	}
+
+	h.sendMessage(chatID, fmt.Sprintf("✏️ Колода «%s» переименована в «%s»", previous, deck.Name))
+}
+
+// setCurrentDeck выбирает колоду для новых слов; nil - без колоды
+func (h *SimpleHandler) setCurrentDeck(ctx context.Context, chatID int64, deck *domain.Deck) {
+	deckID := 0
+	response := "📥 Новые слова будут добавляться без колоды"
+	if deck != nil {
+		deckID = deck.ID
+		response = fmt.Sprintf("📥 Новые слова будут добавляться в колоду «%s»", deck.Name)
+	}
+
+	if err := h.deckService.SetCurrentDeck(ctx, chatID, deckID); err != nil {
+		log.Printf("❌ Error setting current deck: %v", err)
+		h.sendMessage(chatID, "❌ Не удалось выбрать колоду")
+		return
+	}
+
+	h.sendMessage(chatID, response)
+}
+
+// handleMoveCommand: /move слово | колода; вместо колоды "-" убирает слово из колоды
+func (h *SimpleHandler) handleMoveCommand(ctx context.Context, chatID int64, args string) {
+	original, deckName, ok := strings.Cut(args, "|")
+	original, deckName = strings.TrimSpace(original), strings.TrimSpace(deckName)
+	if !ok || original == "" || deckName == "" {
+		h.sendMessage(chatID, "❌ Формат: /move слово | колода\nЧтобы убрать слово из колоды: /move слово | -")
+		return
+	}
+
+	var deck *domain.Deck
+	if deckName != "-" {
+		if deck = h.findDeckOrReport(ctx, chatID, deckName); deck == nil {
+			return
+		}
+	}
+
+	deckID := 0
+	if deck != nil {
+		deckID = deck.ID
+	}
+
+	word, err := h.deckService.MoveWord(ctx, chatID, original, deckID)
+	if err != nil {
+		log.Printf("❌ Error moving word: %v", err)
+		h.sendMessage(chatID, "❌ Не удалось перенести слово")
+		return
+	}
+	if word == nil {
+		h.sendMessage(chatID, fmt.Sprintf("❌ Слово «%s» не найдено в вашем словаре", original))
+		return
+	}
+
+	if deck == nil {
+		h.sendMessage(chatID, fmt.Sprintf("✅ Слово *%s* убрано из колоды", word.Original))
+		return
+	}
+	h.sendMessage(chatID, fmt.Sprintf("✅ Слово *%s* перенесено в колоду «%s»", word.Original, deck.Name))
+}
+
+// handleDeckCallback выбирает текущую колоду из списка; 0 - без колоды
+func (h *SimpleHandler) handleDeckCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+
+	deckID, err := data.Int(0)
+	if err != nil {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return
+	}
+
+	if err := h.deckService.SetCurrentDeck(ctx, chatID, deckID); err != nil {
+		log.Printf("❌ Error setting current deck: %v", err)
+		h.answerCallback(query.ID, "❌ Колода не найдена")
+		return
+	}
+
+	h.answerCallback(query.ID, "📥 Колода для новых слов выбрана")
+
+	text, keyboard, err := h.deckList(ctx, chatID)
+	if err != nil {
+		log.Printf("❌ Error loading decks: %v", err)
+		return
+	}
+	h.editMessage(chatID, query.Message.MessageID, text, &keyboard)
+}
+
+// deckList формирует список колод с кнопками выбора текущей колоды и повторения
+func (h *SimpleHandler) deckList(ctx context.Context, chatID int64) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	decks, err := h.deckService.GetUserDecks(ctx, chatID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	user, err := h.userService.GetUser(ctx, chatID)
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+
+	if len(decks) == 0 {
+		text := "📂 *Колоды*\n\nУ вас пока нет колод. Колоды помогают разделить слова по темам, например «Путешествия» и «Работа».\n\n" + deckCommandsHelp
+		return text, tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(callbackButton("➕ Добавить слово", callbackAddWord)),
+		), nil
+	}
+
+	var text strings.Builder
+	text.WriteString("📂 *Ваши колоды*\n\n")
+
+	current := "без колоды"
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, deck := range decks {
+		mark := "•"
+		label := "📥 " + deck.Name
+		if deck.ID == user.CurrentDeckID {
+			mark, label = "✅", "✓ "+deck.Name
+			current = fmt.Sprintf("в колоду «%s»", deck.Name)
+		}
+		text.WriteString(fmt.Sprintf("%s *%s* #%d - слов: %d\n", mark, deck.Name, deck.ID, deck.WordCount))
+
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			callbackButton(label, callbackDeck, deck.ID),
+			callbackButton("🔁 Повторить", callbackReview, constants.ReviewModeTyping, deck.ID),
+		))
+	}
+
+	noDeckLabel := "📥 Без колоды"
+	if user.CurrentDeckID == 0 {
+		noDeckLabel = "✓ Без колоды"
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(callbackButton(noDeckLabel, callbackDeck, 0)))
+
+	text.WriteString(fmt.Sprintf("\nНовые слова добавляются %s.\n\n%s", current, deckCommandsHelp))
+
+	return text.String(), tgbotapi.NewInlineKeyboardMarkup(rows...), nil
+}
+
+// findDeckOrReport ищет колоду по имени и сообщает пользователю, если её нет
+func (h *SimpleHandler) findDeckOrReport(ctx context.Context, chatID int64, name string) *domain.Deck {
+	if name == "" {
+		h.sendMessage(chatID, "❌ Укажите название колоды")
+		return nil
+	}
+
+	deck, err := h.deckService.FindDeck(ctx, chatID, name)
+	// Колоду можно указать и номером из списка /deck, если колоды с таким названием нет
+	if id, parseErr := strconv.Atoi(strings.TrimPrefix(name, "#")); err == nil && deck == nil && parseErr == nil {
+		deck, err = h.deckService.GetDeck(ctx, chatID, id)
+	}
+	if err != nil {
+		log.Printf("❌ Error finding deck: %v", err)
+		h.sendMessage(chatID, "❌ Не удалось загрузить колоды")
+		return nil
+	}
+
+	if deck == nil {
+		h.sendMessage(chatID, fmt.Sprintf("❌ Колода «%s» не найдена. Список колод: /deck", name))
+		return nil
+	}
+
+	return deck
+}
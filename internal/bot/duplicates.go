@@ -0,0 +1,129 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"ivanSaichkin/language-bot/internal/domain"
+	"ivanSaichkin/language-bot/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Отмена в ответ на вопрос о повторах; остальные аргументы кнопок - service.Duplicate*
+const duplicateArgCancel = "no"
+
+var duplicateActionLabels = map[string]string{
+	service.DuplicateSkip:  "пропустить",
+	service.DuplicateMerge: "объединить",
+	service.DuplicateKeep:  "оставить оба",
+}
+
+// pendingDuplicates - новые слова, которые повторяют слова словаря и ждут решения пользователя
+type pendingDuplicates struct {
+	words []*domain.Word
+}
+
+// askAboutDuplicate показывает, какое слово словаря повторяет новое, и спрашивает, что с ним сделать
+func (h *SimpleHandler) askAboutDuplicate(ctx context.Context, chatID int64, word *domain.Word) {
+	matches, err := h.wordService.FindDuplicates(ctx, chatID, []*domain.Word{word})
+	if err != nil || len(matches) == 0 {
+		if err != nil {
+			log.Printf("❌ Error finding duplicates: %v", err)
+		}
+		h.sendMessage(chatID, "❌ Не удалось добавить слово")
+		return
+	}
+
+	match := matches[0]
+	existing := match.Existing
+
+	var text strings.Builder
+	if match.Exact {
+		text.WriteString(fmt.Sprintf("♻️ Слово *%s* уже есть в словаре:\n", existing.Original))
+	} else {
+		text.WriteString("♻️ В словаре есть очень похожее слово:\n")
+	}
+	text.WriteString(fmt.Sprintf("• *%s* - %s\n\nНовое:\n• *%s* - %s\n\n", existing.Original, existing.DisplayTranslation(),
+		word.Original, word.DisplayTranslation()))
+	text.WriteString(fmt.Sprintf("Объединить - переводы нового слова добавятся к «%s», прогресс повторений сохранится.\nОставить оба - в словаре будут два отдельных слова.",
+		existing.Original))
+
+	h.stateMu.Lock()
+	h.duplicates[chatID] = &pendingDuplicates{words: []*domain.Word{word}}
+	h.stateMu.Unlock()
+	h.sendMessageWithKeyboard(chatID, text.String(), duplicateKeyboard())
+}
+
+// handleDuplicateCallback объединяет повторы со словами словаря, сохраняет их отдельно или отменяет добавление
+func (h *SimpleHandler) handleDuplicateCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+	messageID := query.Message.MessageID
+
+	h.stateMu.Lock()
+	pending, exists := h.duplicates[chatID]
+	h.stateMu.Unlock()
+	if !exists {
+		h.answerCallback(query.ID, "Решение уже принято")
+		h.removeKeyboard(chatID, messageID)
+		return
+	}
+
+	action := data.Arg(0)
+	if action != service.DuplicateMerge && action != service.DuplicateKeep {
+		h.stateMu.Lock()
+		delete(h.duplicates, chatID)
+		h.stateMu.Unlock()
+		h.answerCallback(query.ID, "")
+		h.editMessage(chatID, messageID, "❌ Повторы не добавлены", nil)
+		return
+	}
+	h.stateMu.Lock()
+	delete(h.duplicates, chatID)
+	h.stateMu.Unlock()
+
+	result, err := h.wordService.AddWords(ctx, chatID, pending.words, action)
+	if err != nil {
+		log.Printf("❌ Error resolving duplicates: %v", err)
+		h.answerCallback(query.ID, "❌ Не удалось сохранить")
+		h.editMessage(chatID, messageID, "❌ Не удалось сохранить слова", nil)
+		return
+	}
+
+	h.answerCallback(query.ID, "✅ Готово")
+	h.editMessage(chatID, messageID, duplicateResolutionText(result), nil)
+}
+
+func duplicateResolutionText(result *service.BulkAddResult) string {
+	var response strings.Builder
+	if len(result.Merged) > 0 {
+		response.WriteString("🔗 *Переводы добавлены к словам:*\n")
+		writeWordList(&response, result.Merged, "•")
+	}
+
+	if len(result.Added) > 0 {
+		if response.Len() > 0 {
+			response.WriteString("\n")
+		}
+		response.WriteString("✅ *Добавлены отдельными словами:*\n")
+		writeWordList(&response, result.Added, "•")
+	}
+
+	if response.Len() == 0 {
+		return "❌ Слова не сохранены"
+	}
+
+	return response.String()
+}
+
+func duplicateKeyboard() tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			callbackButton("🔗 Объединить", callbackDuplicate, service.DuplicateMerge),
+			callbackButton("➕ Оставить оба", callbackDuplicate, service.DuplicateKeep),
+		),
+		tgbotapi.NewInlineKeyboardRow(callbackButton("❌ Отмена", callbackDuplicate, duplicateArgCancel)),
+	)
+}
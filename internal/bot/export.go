@@ -0,0 +1,79 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"ivanSaichkin/language-bot/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+var exportFormatLabels = map[string]string{
+	service.ExportFormatCSV:  "таблица CSV: слова, переводы и расписание повторений",
+	service.ExportFormatJSON: "JSON: слова, расписание и статистика",
+	service.ExportFormatAnki: "текст для импорта в Anki",
+}
+
+// handleExportCommand: /export [csv|json|anki]; без аргументов предлагает выбрать формат
+func (h *SimpleHandler) handleExportCommand(ctx context.Context, chatID int64, args string) {
+	format := strings.ToLower(strings.TrimSpace(args))
+	if format == "" {
+		keyboard := tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				callbackButton("📊 CSV", callbackExport, service.ExportFormatCSV),
+				callbackButton("🗂 JSON", callbackExport, service.ExportFormatJSON),
+				callbackButton("📇 Anki", callbackExport, service.ExportFormatAnki),
+			),
+		)
+		h.sendMessageWithKeyboard(chatID, `📤 *Экспорт словаря*
+
+• /export csv - таблица CSV: слова, переводы и расписание повторений
+• /export json - JSON: слова, расписание и статистика
+• /export anki - текст для импорта в Anki (Файл → Импорт)`, keyboard)
+		return
+	}
+
+	if _, ok := exportFormatLabels[format]; !ok {
+		h.sendMessage(chatID, "❌ Неизвестный формат. Используйте: /export csv, /export json или /export anki")
+		return
+	}
+
+	h.sendExport(ctx, chatID, format)
+}
+
+func (h *SimpleHandler) handleExportCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	format := data.Arg(0)
+	if _, ok := exportFormatLabels[format]; !ok {
+		h.answerCallback(query.ID, "❌ Неизвестный формат")
+		return
+	}
+
+	h.answerCallback(query.ID, "⏳ Готовлю файл...")
+	h.sendExport(ctx, query.Message.Chat.ID, format)
+}
+
+// sendExport формирует файл и отправляет его документом
+func (h *SimpleHandler) sendExport(ctx context.Context, chatID int64, format string) {
+	file, err := h.exportService.Export(ctx, chatID, format)
+	if err != nil {
+		log.Printf("❌ Error exporting words: %v", err)
+		h.sendMessage(chatID, "❌ Не удалось выгрузить слова")
+		return
+	}
+
+	if file.WordCount == 0 {
+		h.sendMessage(chatID, "📝 В словаре пока нет слов для экспорта. Добавьте их через /add")
+		return
+	}
+
+	document := tgbotapi.NewDocument(chatID, tgbotapi.FileBytes{Name: file.Name, Bytes: file.Data})
+	document.Caption = fmt.Sprintf("📤 Слов: %d, %s", file.WordCount, exportFormatLabels[format])
+
+	if _, err := h.bot.Send(document); err != nil {
+		log.Printf("❌ Error sending document: %v", err)
+		h.sendMessage(chatID, "❌ Не удалось отправить файл")
+	}
+}
@@ -0,0 +1,124 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"ivanSaichkin/language-bot/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Сколько слов показывает /find
+const findResultLimit = 10
+
+// handleFindCommand: /find текст - поиск по словам, переводам и примерам
+func (h *SimpleHandler) handleFindCommand(ctx context.Context, chatID int64, args string) {
+	query := strings.TrimSpace(args)
+	if query == "" {
+		h.sendMessage(chatID, "🔎 Укажите, что искать: /find текст\n\nИщу в словах, переводах и примерах, без учёта регистра и диакритики, а также с опечатками.")
+		return
+	}
+
+	matches, err := h.wordService.FindWords(ctx, chatID, query, findResultLimit)
+	if err != nil {
+		log.Printf("❌ Error searching words: %v", err)
+		h.sendMessage(chatID, "❌ Не удалось выполнить поиск")
+		return
+	}
+
+	if len(matches) == 0 {
+		h.sendMessage(chatID, fmt.Sprintf("🔎 По запросу «%s» ничего не найдено", query))
+		return
+	}
+
+	text, keyboard := findResultsView(query, matches)
+	h.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// handleResetProgressCallback спрашивает подтверждение и сбрасывает прогресс слова
+func (h *SimpleHandler) handleResetProgressCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+	messageID := query.Message.MessageID
+
+	switch data.Arg(1) {
+	case "":
+		word := h.callbackWord(ctx, query, data, 0)
+		if word == nil {
+			return
+		}
+
+		h.answerCallback(query.ID, "")
+		text := fmt.Sprintf("🔄 Сбросить прогресс слова *%s* - %s?\n\nПовторений: %d, правильных ответов подряд: %d. Слово снова станет новым и попадёт в очередь повторения.",
+			word.Original, word.DisplayTranslation(), word.ReviewCount, word.CorrectAnswers)
+		keyboard := tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			callbackButton("🔄 Сбросить", callbackResetWord, word.ID, editArgConfirm),
+			callbackButton("Отмена", callbackResetWord, word.ID, editArgCancel),
+		))
+		h.editMessage(chatID, messageID, text, &keyboard)
+		return
+	case editArgCancel:
+		h.answerCallback(query.ID, "")
+		h.editMessage(chatID, messageID, "❌ Сброс прогресса отменён", nil)
+		return
+	}
+
+	wordID, err := data.Int(0)
+	if err != nil {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return
+	}
+
+	word, err := h.wordService.ResetWordProgress(ctx, chatID, wordID)
+	if isWordUnavailable(err) {
+		h.answerCallback(query.ID, "❌ Слово не найдено")
+		h.editMessage(chatID, messageID, "❌ Слово не найдено в вашем словаре", nil)
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Error resetting word progress: %v", err)
+		h.answerCallback(query.ID, "❌ Не удалось сбросить прогресс")
+		return
+	}
+
+	h.answerCallback(query.ID, "🔄 Прогресс сброшен")
+	h.editMessage(chatID, messageID, fmt.Sprintf("🔄 Прогресс слова *%s* сброшен, оно снова в очереди повторения", word.Original), nil)
+}
+
+// findResultsView - список найденных слов, под каждым словом кнопки правки, удаления и сброса
+func findResultsView(query string, matches []*service.WordMatch) (string, tgbotapi.InlineKeyboardMarkup) {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("🔎 Найдено по запросу «%s»: %d\n\n", query, len(matches)))
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	hasFuzzy := false
+	for i, match := range matches {
+		word := match.Word
+
+		marker := ""
+		if match.Fuzzy {
+			marker = " ≈"
+			hasFuzzy = true
+		}
+
+		text.WriteString(fmt.Sprintf("%d. *%s* - %s%s\n", i+1, word.Original, word.DisplayTranslation(), marker))
+		if word.Example != "" {
+			text.WriteString(fmt.Sprintf("    _%s_\n", word.Example))
+		}
+
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			callbackButton(fmt.Sprintf("%d. ✏️ %s", i+1, word.Original), callbackWordPick, wordPurposeEdit, word.ID),
+			callbackButton("🗑", callbackWordPick, wordPurposeDelete, word.ID),
+			callbackButton("🔄", callbackResetWord, word.ID),
+		))
+	}
+
+	if hasFuzzy {
+		text.WriteString("\n≈ - слово похоже на запрос: возможно, в запросе опечатка")
+	}
+	text.WriteString("\n✏️ изменить · 🗑 удалить · 🔄 сбросить прогресс")
+
+	return text.String(), tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
@@ -2,11 +2,14 @@ package bot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
+	"sync"
 	"time"
 
+	"ivanSaichkin/language-bot/internal/constants"
 	"ivanSaichkin/language-bot/internal/domain"
 	"ivanSaichkin/language-bot/internal/service"
 
@@ -17,32 +20,70 @@ type SimpleHandler struct {
 	bot               *tgbotapi.BotAPI
 	userService       service.UserService
 	wordService       service.WordService
+	deckService       service.DeckService
+	importService     service.ImportService
+	exportService     service.ExportService
 	reviewService     service.ReviewService
+	quizService       service.QuizService
+	sprintService     service.SprintService
+	matchService      service.MatchService
 	statsService      service.StatsService
 	sessionService    service.SessionService
 	repetitionService service.SpacedRepetitionService
 	sessions          map[int64]*domain.ReviewSession
+	quizzes           map[int64]*domain.Quiz
+	sprints           map[int64]*activeSprint
+	sprintsMu         sync.Mutex // защищает sprints: спринт завершается по таймеру из отдельной горутины
+	matches           map[int64]*activeMatch
+	imports           map[int64]*pendingImport
+	edits             map[int64]*pendingEdit
+	duplicates        map[int64]*pendingDuplicates
+	chatLocks         map[int64]*sync.Mutex
+	stateMu           sync.Mutex // защищает sessions, quizzes, matches, imports, edits, duplicates и chatLocks
+	callbacks         map[string]callbackHandlerFunc
 }
 
 func NewSimpleHandler(
 	bot *tgbotapi.BotAPI,
 	userService service.UserService,
 	wordService service.WordService,
+	deckService service.DeckService,
+	importService service.ImportService,
+	exportService service.ExportService,
 	reviewService service.ReviewService,
+	quizService service.QuizService,
+	sprintService service.SprintService,
+	matchService service.MatchService,
 	statsService service.StatsService,
 	sessionService service.SessionService,
 	repetitionService service.SpacedRepetitionService,
 ) *SimpleHandler {
-	return &SimpleHandler{
+	h := &SimpleHandler{
 		bot:               bot,
 		userService:       userService,
 		wordService:       wordService,
+		deckService:       deckService,
+		importService:     importService,
+		exportService:     exportService,
 		reviewService:     reviewService,
+		quizService:       quizService,
+		sprintService:     sprintService,
+		matchService:      matchService,
 		statsService:      statsService,
 		sessionService:    sessionService,
 		repetitionService: repetitionService,
 		sessions:          make(map[int64]*domain.ReviewSession),
+		quizzes:           make(map[int64]*domain.Quiz),
+		sprints:           make(map[int64]*activeSprint),
+		matches:           make(map[int64]*activeMatch),
+		imports:           make(map[int64]*pendingImport),
+		edits:             make(map[int64]*pendingEdit),
+		duplicates:        make(map[int64]*pendingDuplicates),
+		chatLocks:         make(map[int64]*sync.Mutex),
 	}
+	h.registerCallbacks()
+
+	return h
 }
 
 func (h *SimpleHandler) loadUserSessions(ctx context.Context, userID int64) {
@@ -54,12 +95,27 @@ func (h *SimpleHandler) loadUserSessions(ctx context.Context, userID int64) {
 
 	for _, session := range sessions {
 		if !session.IsCompleted {
-			h.sessions[userID] = session
+			h.setReviewSession(userID, session)
 			log.Printf("🔄 Loaded active session for user %d: %s", userID, session.ID)
 		}
 	}
 }
 
+// reviewSession возвращает сессию повторения чата; обновления разных чатов идут параллельно
+func (h *SimpleHandler) reviewSession(chatID int64) (*domain.ReviewSession, bool) {
+	h.stateMu.Lock()
+	defer h.stateMu.Unlock()
+
+	session, exists := h.sessions[chatID]
+	return session, exists
+}
+
+func (h *SimpleHandler) setReviewSession(chatID int64, session *domain.ReviewSession) {
+	h.stateMu.Lock()
+	h.sessions[chatID] = session
+	h.stateMu.Unlock()
+}
+
 func (h *SimpleHandler) saveSession(ctx context.Context, session *domain.ReviewSession) {
 	if err := h.sessionService.SaveSession(ctx, session); err != nil {
 		log.Printf("⚠️ Failed to save session %s: %v", session.ID, err)
@@ -76,16 +132,60 @@ func (h *SimpleHandler) deleteSession(ctx context.Context, sessionID string) {
 	}
 }
 
+// lockChat захватывает мьютекс чата и возвращает функцию, которая его отпускает
+func (h *SimpleHandler) lockChat(chatID int64) func() {
+	h.stateMu.Lock()
+	lock, exists := h.chatLocks[chatID]
+	if !exists {
+		lock = &sync.Mutex{}
+		h.chatLocks[chatID] = lock
+	}
+	h.stateMu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+func updateChatID(update tgbotapi.Update) (int64, bool) {
+	switch {
+	case update.CallbackQuery != nil && update.CallbackQuery.Message != nil:
+		return update.CallbackQuery.Message.Chat.ID, true
+	case update.Message != nil:
+		return update.Message.Chat.ID, true
+	case update.EditedMessage != nil:
+		return update.EditedMessage.Chat.ID, true
+	}
+
+	return 0, false
+}
+
 func (h *SimpleHandler) HandleUpdate(update tgbotapi.Update) {
 	ctx := context.Background()
 
-	if update.Message == nil {
+	// Каждое обновление обрабатывается в своей горутине: обновления одного чата выстраиваем
+	// в очередь, чтобы два быстрых нажатия не меняли одну игру или тест одновременно
+	if chatID, ok := updateChatID(update); ok {
+		unlock := h.lockChat(chatID)
+		defer unlock()
+	}
+
+	switch {
+	case update.CallbackQuery != nil:
+		h.handleCallbackQuery(ctx, update.CallbackQuery)
+		return
+	case update.InlineQuery != nil:
+		h.handleInlineQuery(ctx, update.InlineQuery)
+		return
+	case update.EditedMessage != nil:
+		h.handleEditedMessage(ctx, update.EditedMessage)
+		return
+	case update.Message == nil:
 		return
 	}
 
 	userID := update.Message.Chat.ID
 
-	if _, exists := h.sessions[userID]; !exists {
+	if _, exists := h.reviewSession(userID); !exists {
 		h.loadUserSessions(ctx, userID)
 	}
 
@@ -129,19 +229,45 @@ func (h *SimpleHandler) handleCommand(ctx context.Context, update tgbotapi.Updat
 	case "add":
 		h.handleAddCommand(ctx, chatID)
 	case "review":
-		h.handleReviewCommand(ctx, chatID)
+		h.handleReviewCommand(ctx, chatID, update.Message.CommandArguments())
 	case "stats":
 		h.handleStatsCommand(ctx, chatID)
 	case "words":
-		h.handleWordsCommand(ctx, chatID)
+		h.handleWordsCommand(ctx, chatID, update.Message.CommandArguments())
+	case "deck":
+		h.handleDeckCommand(ctx, chatID, update.Message.CommandArguments())
+	case "move":
+		h.handleMoveCommand(ctx, chatID, update.Message.CommandArguments())
+	case "edit":
+		h.handleEditCommand(ctx, chatID, update.Message.CommandArguments())
+	case "delete":
+		h.handleDeleteCommand(ctx, chatID, update.Message.CommandArguments())
+	case "find":
+		h.handleFindCommand(ctx, chatID, update.Message.CommandArguments())
+	case "export":
+		h.handleExportCommand(ctx, chatID, update.Message.CommandArguments())
 	case "test":
-		h.handleTestCommand(ctx, chatID)
+		h.handleTestCommand(ctx, chatID, update.Message.CommandArguments())
+	case "sprint":
+		h.handleSprintCommand(ctx, chatID)
+	case "match":
+		h.handleMatchCommand(ctx, chatID)
 	case "debug":
 		h.handleDebugCommand(ctx, chatID)
 	case "leaderboard":
 		h.handleLeaderboardCommand(ctx, chatID)
 	case "goal":
 		h.handleGoalCommand(ctx, chatID, update.Message.CommandArguments())
+	case "scheduler":
+		h.handleSchedulerCommand(ctx, chatID, update.Message.CommandArguments())
+	case "timezone":
+		h.handleTimezoneCommand(ctx, chatID, update.Message.CommandArguments())
+	case "strictness":
+		h.handleStrictnessCommand(ctx, chatID, update.Message.CommandArguments())
+	case "directions":
+		h.handleDirectionsCommand(ctx, chatID, update.Message.CommandArguments())
+	case "language", "lang":
+		h.handleLanguageCommand(ctx, chatID, update.Message.CommandArguments())
 	default:
 		h.sendMessage(chatID, "❌ Неизвестная команда. Используйте /help для списка команд.")
 	}
@@ -154,6 +280,7 @@ func (h *SimpleHandler) handleStartCommand(ctx context.Context, chatID int64, fr
 
 📚 *Доступные команды:*
 /add - Добавить новое слово
+/language - Выбрать изучаемый язык
 /review - Повторять слова
 /stats - Статистика обучения
 /words - Список всех слов
@@ -162,9 +289,10 @@ func (h *SimpleHandler) handleStartCommand(ctx context.Context, chatID int64, fr
 /help - Помощь
 
 💡 *Быстрый старт:*
-1. Добавьте слова: hello - привет
-2. Повторяйте: /review
-3. Следите за прогрессом: /stats`
+1. Выберите язык: /language
+2. Добавьте слова: hello - привет
+3. Повторяйте: /review
+4. Следите за прогрессом: /stats`
 
 	h.sendMessage(chatID, fmt.Sprintf(response, from.FirstName))
 }
@@ -175,56 +303,151 @@ func (h *SimpleHandler) handleHelpCommand(chatID int64) {
 🎯 *Основные команды:*
 /add - Добавить слово в формате: слово - перевод
 Пример: hello - привет
+Несколько переводов через запятую: house - дом, здание
+Несколько слов сразу: каждое с новой строки
+
+/language - Выбрать изучаемый язык: от него зависят /add, /words, /review и /stats
 
 /review - Начать сессию повторения слов
+/review choice - Повторение с выбором из 4 вариантов
+/review cloze - Упражнения: вставить слово в пример
+/review [режим] <колода> - Повторять только слова из колоды
+/test [число] [язык] [колода] - Проверочный тест, не влияет на расписание повторений
+/sprint - Спринт: минута на то, чтобы угадать как можно больше пар «слово = перевод»
+/match - Игра «Найди пары»: соедините слова с переводами
 /stats - Посмотреть вашу статистику
-/words - Показать все ваши слова
+/words [колода] - Ваши слова на изучаемом языке или слова колоды: листайте, сортируйте, фильтруйте и открывайте карточку слова с историей повторений
+/deck - Колоды: создать, переименовать, удалить, выбрать текущую
+/move слово | колода - Перенести слово в колоду
+/edit [слово] - Исправить слово, перевод, пример, часть речи или язык
+/delete [слово] - Удалить слово из словаря
+/find текст - Найти слова по слову, переводу или примеру, даже с опечаткой
+📄 Пришлите файл CSV или TSV, чтобы импортировать слова: слово, перевод, пример, язык, часть речи, теги
+📦 Пришлите колоду Anki (.apkg), чтобы перенести её слова и, по желанию, расписание повторений
+/export [csv|json|anki] - Выгрузить словарь файлом
 
 📊 *Дополнительные команды:*
 /leaderboard - Таблица лидеров среди пользователей
 /goal [число] - Установить дневную цель (например: /goal 15)
+/scheduler - Выбрать алгоритм повторений (SM-2 или FSRS)
+/timezone - Часовой пояс для подсчёта дневной цели
+/strictness - Насколько строго проверять опечатки
+/directions - Тренировать слова в одну или обе стороны
 /debug - Отладочная информация
 
 💡 *Советы:*
 • Добавляйте слова с примерами: hello - привет | Hello world!
 • Регулярно повторяйте слова с помощью /review
-• Старайтесь достигать дневной цели`
+• После ответа оцените, насколько легко вспомнили слово: Снова / Трудно / Хорошо / Легко
+• Старайтесь достигать дневной цели
+• Чтобы поделиться словом, напишите в любом чате имя бота через @ и начало слова`
 
 	h.sendMessage(chatID, response)
 }
 
 func (h *SimpleHandler) handleAddCommand(ctx context.Context, chatID int64) {
+	user, err := h.userService.GetUser(ctx, chatID)
+	if err != nil {
+		h.sendMessage(chatID, "❌ Не удалось получить информацию о пользователе")
+		return
+	}
+
 	if err := h.userService.SetUserState(ctx, chatID, "awaiting_word"); err != nil {
 		h.sendMessage(chatID, "❌ Ошибка при изменении состояния")
 		return
 	}
 
-	response := `📝 *Добавление нового слова*
+	response := fmt.Sprintf(`📝 *Добавление нового слова*
+
+Язык: *%s* (сменить: /language)
 
 Введите слово и перевод через тире:
-• Английский: hello - привет
+• Простое слово: hello - привет
+• Несколько переводов: house - дом, здание
 • С примером: book - книга | I read a book
 
-Поддерживаемые языки: английский (en), немецкий (de), французский (fr)`
+Можно отправить сразу список: каждое слово с новой строки.`, domain.LanguageLabel(user.StudyLanguage))
 
 	h.sendMessage(chatID, response)
 }
 
-func (h *SimpleHandler) handleReviewCommand(ctx context.Context, chatID int64) {
-	if session, exists := h.sessions[chatID]; exists && !session.IsCompleted {
-		h.sendMessage(chatID, "🔁 У вас уже есть активная сессия. Продолжайте отвечать на вопросы.")
+func (h *SimpleHandler) handleReviewCommand(ctx context.Context, chatID int64, args string) {
+	// Первое слово аргументов - режим, если он указан, остальное - название колоды
+	mode, deckName := constants.ReviewModeTyping, strings.TrimSpace(args)
+	if fields := strings.Fields(deckName); len(fields) > 0 {
+		if parsed, ok := parseReviewMode(fields[0]); ok {
+			mode, deckName = parsed, strings.TrimSpace(strings.TrimPrefix(deckName, fields[0]))
+		}
+	}
+
+	deckID := 0
+	if deckName != "" {
+		deck := h.findDeckOrReport(ctx, chatID, deckName)
+		if deck == nil {
+			return
+		}
+		deckID = deck.ID
+	}
+
+	h.startReview(ctx, chatID, mode, deckID)
+}
+
+// startReview начинает сессию в указанном режиме по словам колоды,
+// а если колода не указана (deckID = 0) - по словам изучаемого языка
+func (h *SimpleHandler) startReview(ctx context.Context, chatID int64, mode string, deckID int) {
+	if session, exists := h.reviewSession(chatID); exists && !session.IsCompleted {
+		keyboard := tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(
+				callbackButton("▶️ Продолжить", callbackReview),
+				callbackButton("⏹ Завершить", callbackStopReview),
+			),
+		)
+		h.sendMessageWithKeyboard(chatID, "🔁 У вас уже есть активная сессия. Продолжайте отвечать на вопросы.", keyboard)
 		return
 	}
 
-	session, err := h.reviewService.StartReviewSession(ctx, chatID, 10)
+	h.stateMu.Lock()
+	quiz, exists := h.quizzes[chatID]
+	h.stateMu.Unlock()
+	if exists && !quiz.IsCompleted {
+		h.sendMessage(chatID, "🧪 Сначала завершите тест или прервите его: /test stop")
+		return
+	}
+
+	if mode == constants.ReviewModeCloze {
+		if created, err := h.wordService.CreateClozeCards(ctx, chatID); err != nil {
+			log.Printf("⚠️ Failed to create cloze cards: %v", err)
+		} else if created > 0 {
+			h.sendMessage(chatID, fmt.Sprintf("🧩 Созданы упражнения с пропусками из ваших примеров: %d", created))
+		}
+	}
+
+	filter := h.wordFilter(ctx, chatID, deckID)
+	session, err := h.reviewService.StartReviewSession(ctx, chatID, 10, mode, filter)
 	if err != nil {
-		h.sendMessage(chatID, fmt.Sprintf("❌ Не удалось начать сессию: %v", err))
+		keyboard := tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(callbackButton("➕ Добавить слово", callbackAddWord)),
+		)
+		response := fmt.Sprintf("❌ Не удалось начать сессию: %v", err)
+		if filter.Language != "" {
+			response = fmt.Sprintf("🌍 Сейчас нет слов для повторения на языке: %s\nДругой язык: /language",
+				domain.LanguageLabel(filter.Language))
+		}
+		if deckID != 0 {
+			response = "📂 В этой колоде сейчас нет слов для повторения"
+		}
+		if mode == constants.ReviewModeCloze {
+			response = "🧩 Сейчас нет упражнений с пропусками для повторения.\nОни создаются из примеров: book - книга | I read a book"
+		}
+		h.sendMessageWithKeyboard(chatID, response, keyboard)
 		return
 	}
 
-	h.sessions[chatID] = session
-	h.saveSession(ctx, session)
-	h.sendNextReviewQuestion(chatID, session)
+	h.setReviewSession(chatID, session)
+	if session.IsChoiceMode() {
+		h.sendMessage(chatID, "🔘 *Режим выбора*\nВыберите правильный ответ из вариантов. Узнать слово проще, чем вспомнить, поэтому верный выбор засчитывается как «Трудно».")
+	}
+	h.sendNextReviewQuestion(ctx, chatID, session)
 }
 
 func (h *SimpleHandler) handleStatsCommand(ctx context.Context, chatID int64) {
@@ -246,6 +469,12 @@ func (h *SimpleHandler) handleStatsCommand(ctx context.Context, chatID int64) {
 		return
 	}
 
+	dailyProgress, err := h.statsService.GetDailyProgress(ctx, chatID)
+	if err != nil {
+		h.sendMessage(chatID, "❌ Не удалось загрузить дневной прогресс")
+		return
+	}
+
 	response := fmt.Sprintf(`📊 *Ваша статистика*
 
 🎯 *Слова:*
@@ -260,10 +489,16 @@ func (h *SimpleHandler) handleStatsCommand(ctx context.Context, chatID int64) {
 • Точность: %.1f%%
 • Среднее время: %.1f сек
 
+🎯 *Дневная цель:*
+• Повторено сегодня: %d/%d (%.0f%%)
+• %s
+
 🔥 *Серия:*
 • Текущая серия: %d дней
 • Рекорд: %d дней
-• Сегодня: %s`,
+• Сегодня: %s
+
+🏃 *Рекорд спринта:* %d очков`,
 		wordProgress.TotalWords,
 		wordProgress.LearnedWords,
 		wordProgress.TotalWords-wordProgress.LearnedWords,
@@ -272,68 +507,32 @@ func (h *SimpleHandler) handleStatsCommand(ctx context.Context, chatID int64) {
 		stats.TotalCorrect,
 		stats.GetAccuracy(),
 		stats.GetAverageTime(),
+		dailyProgress.TodayReviewed,
+		dailyProgress.DailyGoal,
+		dailyProgress.CompletionRate,
+		dailyGoalStatus(dailyProgress),
 		streakInfo.CurrentStreak,
 		streakInfo.MaxStreak,
 		map[bool]string{true: "✅ выполнено", false: "⏳ осталось"}[streakInfo.IsTodayCompleted],
+		stats.SprintBest,
 	)
 
-	h.sendMessage(chatID, response)
-}
+	if languages, err := h.wordService.GetLanguageProgress(ctx, chatID); err != nil {
+		log.Printf("⚠️ Failed to get language progress: %v", err)
+	} else if len(languages) > 0 {
+		user, _ := h.userService.GetUser(ctx, chatID)
 
-func (h *SimpleHandler) handleWordsCommand(ctx context.Context, chatID int64) {
-	words, err := h.wordService.GetUserWords(ctx, chatID)
-	if err != nil {
-		h.sendMessage(chatID, "❌ Не удалось загрузить слова")
-		return
-	}
-
-	if len(words) == 0 {
-		h.sendMessage(chatID, "📝 У вас пока нет слов для изучения. Используйте /add чтобы добавить первые слова!")
-		return
-	}
-
-	var response strings.Builder
-	response.WriteString("📖 *Ваши слова*\n\n")
-
-	for i, word := range words {
-		if i >= 15 {
-			response.WriteString(fmt.Sprintf("\n... и ещё %d слов", len(words)-i))
-			break
-		}
-
-		status := "🟢"
-		if word.IsDueForReview() {
-			status = "🟡"
-		}
-		if word.IsLearned() {
-			status = "🔵"
+		response += "\n\n🌍 *По языкам:*"
+		for _, language := range languages {
+			mark := ""
+			if user != nil && user.StudyLanguage == language.Language {
+				mark = " ← изучаете"
+			}
+			response += fmt.Sprintf("\n• %s: %d слов, выучено %d, к повторению %d%s",
+				domain.LanguageLabel(language.Language), language.TotalWords, language.LearnedWords, language.DueWords, mark)
 		}
-
-		progress := word.GetProgress()
-		response.WriteString(fmt.Sprintf("%s *%s* - %s\n", status, word.Original, word.Translation))
-		response.WriteString(fmt.Sprintf("   📊 Прогресс: %.0f%%, Повторений: %d\n\n", progress, word.ReviewCount))
 	}
 
-	dueWords, _ := h.wordService.GetDueWords(ctx, chatID)
-	if len(dueWords) > 0 {
-		response.WriteString(fmt.Sprintf("⏰ *Слов для повторения: %d* /review", len(dueWords)))
-	}
-
-	h.sendMessage(chatID, response.String())
-}
-
-func (h *SimpleHandler) handleTestCommand(ctx context.Context, chatID int64) {
-	response := `🧪 *Тестовый режим*
-
-Эта команда предназначена для тестирования функциональности бота.
-
-Доступные тестовые команды:
-/debug - отладочная информация
-/stats - просмотр статистики
-/words - список слов
-
-Для начала работы добавьте несколько слов с помощью /add`
-
 	h.sendMessage(chatID, response)
 }
 
@@ -363,14 +562,18 @@ func (h *SimpleHandler) handleDebugCommand(ctx context.Context, chatID int64) {
 			status = "⏰"
 		}
 
-		response.WriteString(fmt.Sprintf("%s %d. %s - %s\n", status, i+1, word.Original, word.Translation))
+		response.WriteString(fmt.Sprintf("%s %d. %s - %s\n", status, i+1, word.Original, word.DisplayTranslation()))
 		response.WriteString(fmt.Sprintf("   Сложность: %.2f, Повторений: %d, Правильно: %d\n",
 			word.Difficulty, word.ReviewCount, word.CorrectAnswers))
+		if word.Stability > 0 {
+			response.WriteString(fmt.Sprintf("   FSRS: S=%.1f дн., D=%.1f, R=%.0f%%\n",
+				word.Stability, word.FSRSDifficulty, word.Retrievability*100))
+		}
 		response.WriteString(fmt.Sprintf("   След. повтор: %s\n\n",
 			word.NextReview.Format("02.01.2006 15:04")))
 	}
 
-	if session, exists := h.sessions[chatID]; exists {
+	if session, exists := h.reviewSession(chatID); exists && !session.IsCompleted {
 		response.WriteString("🔄 *Активная сессия:*\n")
 		response.WriteString(fmt.Sprintf("Слов: %d, Прогресс: %d/%d\n",
 			session.TotalQuestions, session.CurrentIndex+1, session.TotalQuestions))
@@ -429,7 +632,7 @@ func (h *SimpleHandler) handleGoalCommand(ctx context.Context, chatID int64, arg
 			return
 		}
 
-		h.sendMessage(chatID, fmt.Sprintf("🎯 Ваша текущая дневная цель: *%d слов*", user.DailyGoal))
+		h.sendMessageWithKeyboard(chatID, h.goalStatusText(ctx, chatID, user.DailyGoal), goalKeyboard(user.DailyGoal))
 		return
 	}
 
@@ -447,11 +650,197 @@ func (h *SimpleHandler) handleGoalCommand(ctx context.Context, chatID int64, arg
 	h.sendMessage(chatID, fmt.Sprintf("✅ Дневная цель установлена: *%d слов*", goal))
 }
 
+func (h *SimpleHandler) goalStatusText(ctx context.Context, chatID int64, goal int) string {
+	response := fmt.Sprintf("🎯 Ваша текущая дневная цель: *%d слов*", goal)
+	if progress, err := h.statsService.GetDailyProgress(ctx, chatID); err == nil {
+		response += fmt.Sprintf("\nСегодня повторено: *%d*. %s", progress.TodayReviewed, dailyGoalStatus(progress))
+	}
+
+	return response + "\n\nВыберите новую цель или отправьте /goal [число]"
+}
+
+func (h *SimpleHandler) handleGoalCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+
+	goal, err := data.Int(0)
+	if err != nil || goal < 1 || goal > 100 {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return
+	}
+
+	if err := h.userService.UpdateDailyGoal(ctx, chatID, goal); err != nil {
+		h.answerCallback(query.ID, "❌ Не удалось обновить дневную цель")
+		return
+	}
+
+	h.answerCallback(query.ID, fmt.Sprintf("✅ Цель: %d слов", goal))
+
+	keyboard := goalKeyboard(goal)
+	h.editMessage(chatID, query.Message.MessageID, h.goalStatusText(ctx, chatID, goal), &keyboard)
+}
+
+func (h *SimpleHandler) handleSchedulerCommand(ctx context.Context, chatID int64, args string) {
+	fields := strings.Fields(strings.ToLower(args))
+	if len(fields) == 0 {
+		user, err := h.userService.GetUser(ctx, chatID)
+		if err != nil {
+			h.sendMessage(chatID, "❌ Не удалось получить информацию о пользователе")
+			return
+		}
+
+		current := "SM-2"
+		if user.Scheduler == constants.SchedulerFSRS {
+			current = fmt.Sprintf("FSRS (целевое запоминание %.0f%%)", user.DesiredRetention*100)
+		}
+
+		h.sendMessage(chatID, fmt.Sprintf(`🧠 *Алгоритм повторений:* %s
+
+• /scheduler sm2 - классический SM-2
+• /scheduler fsrs [процент] - FSRS с целевым запоминанием, например: /scheduler fsrs 90`, current))
+		return
+	}
+
+	user, err := h.userService.GetUser(ctx, chatID)
+	if err != nil {
+		h.sendMessage(chatID, "❌ Не удалось получить информацию о пользователе")
+		return
+	}
+
+	retention := user.DesiredRetention
+	switch fields[0] {
+	case constants.SchedulerSM2:
+	case constants.SchedulerFSRS:
+		if len(fields) > 1 {
+			var percent float64
+			if _, err := fmt.Sscanf(fields[1], "%f", &percent); err != nil || percent < 70 || percent > 97 {
+				h.sendMessage(chatID, "❌ Целевое запоминание должно быть числом от 70 до 97")
+				return
+			}
+			retention = percent / 100
+		}
+	default:
+		h.sendMessage(chatID, "❌ Неизвестный алгоритм. Используйте: /scheduler sm2 или /scheduler fsrs 90")
+		return
+	}
+
+	if err := h.userService.SetScheduler(ctx, chatID, fields[0], retention); err != nil {
+		h.sendMessage(chatID, "❌ Не удалось изменить алгоритм повторений")
+		return
+	}
+
+	if fields[0] == constants.SchedulerFSRS {
+		h.sendMessage(chatID, fmt.Sprintf("✅ Включён FSRS, целевое запоминание: *%.0f%%*", retention*100))
+		return
+	}
+
+	h.sendMessage(chatID, "✅ Включён алгоритм SM-2")
+}
+
+func (h *SimpleHandler) handleTimezoneCommand(ctx context.Context, chatID int64, args string) {
+	timezone := strings.TrimSpace(args)
+	if timezone == "" {
+		user, err := h.userService.GetUser(ctx, chatID)
+		if err != nil {
+			h.sendMessage(chatID, "❌ Не удалось получить информацию о пользователе")
+			return
+		}
+
+		current := user.Timezone
+		if current == "" {
+			current = "не задан (время сервера)"
+		}
+
+		h.sendMessage(chatID, fmt.Sprintf(`🕒 *Часовой пояс:* %s
+
+Он нужен, чтобы считать повторения за ваш день.
+Пример: /timezone Europe/Moscow или /timezone +3`, current))
+		return
+	}
+
+	if err := h.userService.SetTimezone(ctx, chatID, timezone); err != nil {
+		h.sendMessage(chatID, "❌ Неизвестный часовой пояс. Пример: /timezone Europe/Moscow или /timezone +3")
+		return
+	}
+
+	h.sendMessage(chatID, fmt.Sprintf("✅ Часовой пояс установлен: *%s*", timezone))
+}
+
+func (h *SimpleHandler) handleStrictnessCommand(ctx context.Context, chatID int64, args string) {
+	strictness := strings.ToLower(strings.TrimSpace(args))
+	if strictness == "" {
+		user, err := h.userService.GetUser(ctx, chatID)
+		if err != nil {
+			h.sendMessage(chatID, "❌ Не удалось получить информацию о пользователе")
+			return
+		}
+
+		h.sendMessage(chatID, fmt.Sprintf(`🔤 *Проверка ответов:* %s
+
+• /strictness strict - только точное написание
+• /strictness normal - прощать ё, диакритику и 1-2 опечатки
+• /strictness lenient - прощать больше опечаток в длинных словах`, strictnessLabel(user.AnswerStrictness)))
+		return
+	}
+
+	if err := h.userService.SetAnswerStrictness(ctx, chatID, strictness); err != nil {
+		h.sendMessage(chatID, "❌ Неизвестный режим. Используйте: strict, normal или lenient")
+		return
+	}
+
+	h.sendMessage(chatID, fmt.Sprintf("✅ Проверка ответов: *%s*", strictnessLabel(strictness)))
+}
+
+func (h *SimpleHandler) handleDirectionsCommand(ctx context.Context, chatID int64, args string) {
+	directions := strings.ToLower(strings.TrimSpace(args))
+	if directions == "" {
+		user, err := h.userService.GetUser(ctx, chatID)
+		if err != nil {
+			h.sendMessage(chatID, "❌ Не удалось получить информацию о пользователе")
+			return
+		}
+
+		h.sendMessage(chatID, fmt.Sprintf(`🔄 *Направления карточек:* %s
+
+• /directions forward - только слово → перевод
+• /directions both - ещё и перевод → слово (отдельное расписание повторений)`, directionsLabel(user.CardDirections)))
+		return
+	}
+
+	if err := h.userService.SetCardDirections(ctx, chatID, directions); err != nil {
+		h.sendMessage(chatID, "❌ Неизвестный режим. Используйте: forward или both")
+		return
+	}
+
+	response := fmt.Sprintf("✅ Направления карточек: *%s*", directionsLabel(directions))
+	if directions == constants.CardDirectionsBoth {
+		created, err := h.wordService.CreateReverseCards(ctx, chatID)
+		if err != nil {
+			log.Printf("⚠️ Failed to create reverse cards: %v", err)
+		} else if created > 0 {
+			response += fmt.Sprintf("\nСоздано обратных карточек для ваших слов: %d", created)
+		}
+	} else {
+		response += "\nУже созданные обратные карточки продолжат появляться в повторениях"
+	}
+
+	h.sendMessage(chatID, response)
+}
+
 func (h *SimpleHandler) handleMessage(ctx context.Context, update tgbotapi.Update) {
 	chatID := update.Message.Chat.ID
 	text := update.Message.Text
 
-	if session, exists := h.sessions[chatID]; exists && !session.IsCompleted {
+	if update.Message.Document != nil {
+		h.handleDocument(ctx, chatID, update.Message.Document)
+		return
+	}
+
+	if session, exists := h.reviewSession(chatID); exists && !session.IsCompleted {
+		if session.HasChoices() {
+			h.sendMessage(chatID, "👆 Выберите вариант ответа кнопкой под вопросом")
+			return
+		}
+
 		h.handleReviewAnswer(ctx, chatID, text, session)
 		return
 	}
@@ -465,24 +854,47 @@ func (h *SimpleHandler) handleMessage(ctx context.Context, update tgbotapi.Updat
 	switch state {
 	case "awaiting_word":
 		h.handleWordAddition(ctx, chatID, text)
+	case string(constants.StateInTest):
+		h.handleQuizAnswer(ctx, chatID, text)
+	case string(constants.StateAwaitingLanguage):
+		h.handleLanguageInput(ctx, chatID, text)
+	case string(constants.StateAwaitingEdit):
+		h.handleEditInput(ctx, chatID, text)
 	default:
 		h.sendMessage(chatID, "💡 Используйте команды для взаимодействия с ботом. /help - список команд")
 	}
 }
 
 func (h *SimpleHandler) handleWordAddition(ctx context.Context, chatID int64, text string) {
-	original, translation, example, ok := parseWordInput(text)
+	// Несколько непустых строк - список слов, по слову на строке
+	if strings.Contains(strings.TrimSpace(text), "\n") {
+		h.handleBulkWordAddition(ctx, chatID, text)
+		return
+	}
+
+	original, translations, example, ok := parseWordInput(text)
 	if !ok {
-		h.sendMessage(chatID, "❌ Неверный формат. Используйте: слово - перевод | пример")
+		h.sendMessage(chatID, "❌ Неверный формат. Используйте: слово - перевод1, перевод2 | пример")
 		return
 	}
 
-	word := domain.NewWord(chatID, original, translation, "en")
-	if example != "" {
-		word.WithExample(example)
+	user, err := h.userService.GetUser(ctx, chatID)
+	if err != nil {
+		h.sendMessage(chatID, "❌ Не удалось получить информацию о пользователе")
+		return
 	}
 
-	if err := h.wordService.AddWord(ctx, word); err != nil {
+	word := newWordFromInput(chatID, user.StudyLanguage, original, translations, example)
+
+	err = h.wordService.AddWord(ctx, word)
+	if errors.Is(err, domain.ErrDuplicateWord) {
+		if err := h.userService.SetUserState(ctx, chatID, ""); err != nil {
+			log.Printf("⚠️ Failed to reset user state: %v", err)
+		}
+		h.askAboutDuplicate(ctx, chatID, word)
+		return
+	}
+	if err != nil {
 		h.sendMessage(chatID, "❌ Не удалось добавить слово")
 		return
 	}
@@ -491,11 +903,23 @@ func (h *SimpleHandler) handleWordAddition(ctx context.Context, chatID int64, te
 		log.Printf("⚠️ Failed to reset user state: %v", err)
 	}
 
-	response := fmt.Sprintf("✅ Слово добавлено:\n\n*%s* - %s", word.Original, word.Translation)
+	response := fmt.Sprintf("✅ Слово добавлено:\n\n*%s* - %s", word.Original, word.DisplayTranslation())
 	if word.Example != "" {
 		response += fmt.Sprintf("\n📝 Пример: %s", word.Example)
 	}
 
+	// Похожие по написанию слова с другим переводом не повторы, но их легко перепутать
+	similar, err := h.wordService.FindSimilarWords(ctx, chatID, word)
+	if err != nil {
+		log.Printf("⚠️ Failed to find similar words: %v", err)
+	}
+	if len(similar) > 0 {
+		response += "\n\n💡 Не перепутайте с похожими словами из словаря:"
+		for _, other := range similar {
+			response += fmt.Sprintf("\n• *%s* - %s", other.Original, other.DisplayTranslation())
+		}
+	}
+
 	h.sendMessage(chatID, response)
 }
 
@@ -506,9 +930,10 @@ func (h *SimpleHandler) handleReviewAnswer(ctx context.Context, chatID int64, an
 		return
 	}
 
-	originalWord := currentWordBefore.Original
+	originalWord := currentWordBefore.Prompt()
 
-	result, err := h.reviewService.ProcessAnswer(ctx, session, answer)
+	progress := h.dailyProgress(ctx, chatID)
+	result, err := h.reviewService.ProcessAnswer(ctx, session, answer, domain.GradeAuto)
 	if err != nil {
 		h.sendMessage(chatID, "❌ Ошибка при обработке ответа")
 		log.Printf("❌ Error processing answer: %v", err)
@@ -516,47 +941,344 @@ func (h *SimpleHandler) handleReviewAnswer(ctx context.Context, chatID int64, an
 	}
 
 	var response string
-	if result.IsCorrect {
+	if result.Match == service.MatchClose {
+		response = fmt.Sprintf("✅ *%s* - %s\n✏️ Почти верно, правильное написание: *%s*",
+			originalWord, answer, result.MatchedTranslation)
+	} else if result.IsCorrect {
 		response = fmt.Sprintf("✅ *%s* - %s", originalWord, result.CorrectAnswer)
 	} else {
 		response = fmt.Sprintf("❌ *%s* - %s\nПравильный ответ: *%s*",
 			originalWord, answer, result.CorrectAnswer)
 	}
+	if result.ResponseTime > 0 {
+		response += fmt.Sprintf("\n⏱ %.1f сек", result.ResponseTime.Seconds())
+	}
+	response += "\n\n_Оценка выставлена автоматически, её можно изменить:_"
 
-	h.sendMessage(chatID, response)
+	h.sendMessageWithKeyboard(chatID, response, gradeKeyboard(result.WordID, result.Grade))
+
+	h.notifyDailyGoal(ctx, chatID, progress)
+
+	h.continueReview(ctx, chatID, session, result)
+}
+
+// dailyProgress возвращает прогресс дневной цели или nil, если его не удалось получить
+func (h *SimpleHandler) dailyProgress(ctx context.Context, chatID int64) *service.DailyProgress {
+	progress, err := h.statsService.GetDailyProgress(ctx, chatID)
+	if err != nil {
+		log.Printf("⚠️ Failed to get daily progress: %v", err)
+		return nil
+	}
+
+	return progress
+}
 
+// notifyDailyGoal поздравляет пользователя, если ответ довёл его до дневной цели.
+// before - прогресс до ответа: поздравление приходит один раз, даже если цель перешагнули.
+func (h *SimpleHandler) notifyDailyGoal(ctx context.Context, chatID int64, before *service.DailyProgress) {
+	if before == nil || before.IsGoalAchieved {
+		return
+	}
+
+	after := h.dailyProgress(ctx, chatID)
+	if after != nil && after.IsGoalAchieved {
+		h.sendMessage(chatID, fmt.Sprintf("🎉 *Дневная цель выполнена!* Сегодня вы повторили %d слов. Так держать!",
+			after.TodayReviewed))
+	}
+}
+
+// continueReview показывает следующий вопрос или итоги завершённой сессии
+func (h *SimpleHandler) continueReview(ctx context.Context, chatID int64, session *domain.ReviewSession, result *service.ReviewAnswerResult) {
 	time.Sleep(1 * time.Second)
 
 	if result.SessionProgress.IsComplete {
 		h.reviewService.CompleteReviewSession(ctx, session)
-		delete(h.sessions, chatID)
 
 		h.saveSession(ctx, session)
 
 		h.showSessionResults(chatID, session)
 	} else {
-		h.sendNextReviewQuestion(chatID, session)
+		h.sendNextReviewQuestion(ctx, chatID, session)
 	}
 }
 
-func (h *SimpleHandler) sendNextReviewQuestion(chatID int64, session *domain.ReviewSession) {
+func (h *SimpleHandler) sendNextReviewQuestion(ctx context.Context, chatID int64, session *domain.ReviewSession) {
 	currentWord := session.GetCurrentWord()
 	if currentWord == nil {
 		h.sendMessage(chatID, "🎉 Все слова пройдены!")
 		return
 	}
 
-	current, total := session.GetProgress()
-	question := fmt.Sprintf("📚 Слово %d/%d\n\n*%s*", current, total, currentWord.Original)
+	if err := h.reviewService.PrepareChoices(ctx, session); err != nil {
+		log.Printf("⚠️ Failed to prepare answer choices: %v", err)
+	}
 
-	if currentWord.Example != "" {
+	current, total := session.GetProgress()
+	question := fmt.Sprintf("📚 Слово %d/%d\n\n*%s*", current, total, currentWord.Prompt())
+
+	// В карточке с пропуском пример и есть вопрос, а в обратной его не показываем:
+	// в нём обычно есть само слово
+	if currentWord.IsCloze() {
+		hint := "Вставьте пропущенное слово в нужной форме"
+		if session.HasChoices() {
+			hint = "Выберите пропущенное слово"
+		}
+		question = fmt.Sprintf("🧩 Слово %d/%d\n\n%s\n\n_%s (%s)_", current, total, currentWord.Prompt(), hint, currentWord.DisplayTranslation())
+	} else if currentWord.IsReverse() {
+		hint := "Напишите слово на языке оригинала"
+		if session.HasChoices() {
+			hint = "Выберите слово на языке оригинала"
+		}
+		question = fmt.Sprintf("🔄 Слово %d/%d\n\n*%s*\n\n_%s_", current, total, currentWord.Prompt(), hint)
+	} else if currentWord.Example != "" {
 		question += fmt.Sprintf("\n\n📝 %s", currentWord.Example)
 	}
 
 	log.Printf("🔍 Showing word: %s (correct: %s) to user %d",
-		currentWord.Original, currentWord.Translation, chatID)
+		currentWord.Prompt(), currentWord.DisplayAnswer(), chatID)
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			callbackButton("👀 Показать ответ", callbackReveal, currentWord.ID),
+		),
+	)
+	if session.HasChoices() {
+		keyboard = choicesKeyboard(callbackChoice, currentWord.ID, session.Choices)
+	}
+
+	h.sendMessageWithKeyboard(chatID, question, keyboard)
+
+	session.MarkQuestionShown()
+	h.saveSession(ctx, session)
+}
+
+func (h *SimpleHandler) handleRevealCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+
+	wordID, err := data.Int(0)
+	if err != nil {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return
+	}
+
+	session, exists := h.reviewSession(chatID)
+	if !exists || session.IsCompleted {
+		h.answerCallback(query.ID, "Сессия уже завершена")
+		return
+	}
+
+	currentWord := session.GetCurrentWord()
+	if currentWord == nil || currentWord.ID != wordID {
+		h.answerCallback(query.ID, "Этот вопрос уже пройден")
+		return
+	}
+
+	h.answerCallback(query.ID, "")
+
+	response := fmt.Sprintf("👀 *%s* - %s\n\nНасколько легко вы вспомнили перевод?",
+		currentWord.Prompt(), currentWord.DisplayAnswer())
+	h.sendMessageWithKeyboard(chatID, response, gradeKeyboard(currentWord.ID, domain.GradeAuto))
+}
+
+func (h *SimpleHandler) handleGradeCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+
+	wordID, err := data.Int(0)
+	if err != nil {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return
+	}
+	gradeValue, err := data.Int(1)
+	if err != nil {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return
+	}
+	grade := domain.RecallGrade(gradeValue)
+
+	session, exists := h.reviewSession(chatID)
+	if !exists {
+		h.answerCallback(query.ID, "Сессия не найдена")
+		return
+	}
+
+	// Оценка ещё не отвеченного слова после показа ответа
+	if currentWord := session.GetCurrentWord(); !session.IsCompleted && currentWord != nil && currentWord.ID == wordID {
+		progress := h.dailyProgress(ctx, chatID)
+		result, err := h.reviewService.ProcessAnswer(ctx, session, "", grade)
+		if err != nil {
+			log.Printf("❌ Error processing grade: %v", err)
+			h.answerCallback(query.ID, "❌ Ошибка при обработке оценки")
+			return
+		}
+
+		h.answerCallback(query.ID, gradeLabel(grade))
+		h.editMessage(chatID, query.Message.MessageID,
+			fmt.Sprintf("*%s* - %s\nОценка: %s", result.OriginalWord, result.CorrectAnswer, gradeLabel(grade)), nil)
+
+		h.notifyDailyGoal(ctx, chatID, progress)
+		h.continueReview(ctx, chatID, session, result)
+		return
+	}
+
+	// Переоценка уже засчитанного ответа
+	result, err := h.reviewService.RegradeLastAnswer(ctx, session, wordID, grade)
+	if err != nil {
+		log.Printf("⚠️ Failed to regrade answer: %v", err)
+		h.answerCallback(query.ID, "Оценку этого слова уже нельзя изменить")
+		return
+	}
+
+	h.saveSession(ctx, session)
+	h.answerCallback(query.ID, "Оценка изменена: "+gradeLabel(grade))
+
+	keyboard := gradeKeyboard(result.WordID, result.Grade)
+	h.editMessage(chatID, query.Message.MessageID,
+		fmt.Sprintf("*%s* - %s\nОценка: %s", result.OriginalWord, result.CorrectAnswer, gradeLabel(grade)), &keyboard)
+}
+
+func (h *SimpleHandler) handleChoiceCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+
+	wordID, err := data.Int(0)
+	if err != nil {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return
+	}
+	index, err := data.Int(1)
+	if err != nil {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return
+	}
+
+	session, exists := h.reviewSession(chatID)
+	if !exists || session.IsCompleted {
+		h.answerCallback(query.ID, "Сессия уже завершена")
+		return
+	}
+
+	currentWord := session.GetCurrentWord()
+	if currentWord == nil || currentWord.ID != wordID || index < 0 || index >= len(session.Choices) {
+		h.answerCallback(query.ID, "Этот вопрос уже пройден")
+		return
+	}
+
+	choice := session.Choices[index]
+	progress := h.dailyProgress(ctx, chatID)
+	result, err := h.reviewService.ProcessAnswer(ctx, session, choice, domain.GradeAuto)
+	if err != nil {
+		log.Printf("❌ Error processing choice: %v", err)
+		h.answerCallback(query.ID, "❌ Ошибка при обработке ответа")
+		return
+	}
+
+	var response string
+	if result.IsCorrect {
+		h.answerCallback(query.ID, "✅ Верно!")
+		response = fmt.Sprintf("✅ *%s* - %s", result.OriginalWord, result.CorrectAnswer)
+	} else {
+		h.answerCallback(query.ID, "❌ Неверно")
+		response = fmt.Sprintf("❌ *%s* - %s\nПравильный ответ: *%s*", result.OriginalWord, choice, result.CorrectAnswer)
+	}
+	h.editMessage(chatID, query.Message.MessageID, response, nil)
+
+	h.notifyDailyGoal(ctx, chatID, progress)
+	h.continueReview(ctx, chatID, session, result)
+}
+
+// handleReviewCallback продолжает активную сессию или начинает новую в указанном режиме
+func (h *SimpleHandler) handleReviewCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+	h.answerCallback(query.ID, "")
+
+	if session, exists := h.reviewSession(chatID); exists && !session.IsCompleted {
+		h.removeKeyboard(chatID, query.Message.MessageID)
+		h.sendNextReviewQuestion(ctx, chatID, session)
+		return
+	}
+
+	mode, ok := parseReviewMode(data.Arg(0))
+	if !ok {
+		mode = constants.ReviewModeTyping
+	}
+	deckID, _ := data.Int(1)
+
+	h.startReview(ctx, chatID, mode, deckID)
+}
+
+func (h *SimpleHandler) handleStopReviewCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+
+	session, exists := h.reviewSession(chatID)
+	if !exists || session.IsCompleted {
+		h.answerCallback(query.ID, "Сессия уже завершена")
+		return
+	}
+
+	h.answerCallback(query.ID, "⏹ Сессия завершена")
+	h.removeKeyboard(chatID, query.Message.MessageID)
 
-	h.sendMessage(chatID, question)
+	h.reviewService.CompleteReviewSession(ctx, session)
+	h.saveSession(ctx, session)
+	h.showSessionResults(chatID, session)
+}
+
+func (h *SimpleHandler) handleAddWordCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	h.answerCallback(query.ID, "")
+	h.handleAddCommand(ctx, query.Message.Chat.ID)
+}
+
+// handleEditedMessage: правки уже отправленных сообщений не обрабатываем повторно,
+// но подсказываем, если пользователь пытался исправить ответ или слово
+func (h *SimpleHandler) handleEditedMessage(ctx context.Context, message *tgbotapi.Message) {
+	chatID := message.Chat.ID
+	log.Printf("✏️ Edited message from user %d ignored", chatID)
+
+	if session, exists := h.reviewSession(chatID); exists && !session.IsCompleted {
+		h.sendMessage(chatID, "✏️ Исправленный ответ не засчитывается. Отправьте ответ новым сообщением.")
+		return
+	}
+
+	if state, err := h.userService.GetUserState(ctx, chatID); err == nil && state == string(constants.StateAwaitingWord) {
+		h.sendMessage(chatID, "✏️ Отредактированное сообщение не учитывается. Отправьте слово новым сообщением.")
+	}
+}
+
+// handleInlineQuery ищет слова пользователя, чтобы ими можно было поделиться в любом чате
+func (h *SimpleHandler) handleInlineQuery(ctx context.Context, query *tgbotapi.InlineQuery) {
+	words, err := h.wordService.GetUserWords(ctx, query.From.ID)
+	if err != nil {
+		log.Printf("⚠️ Failed to load words for inline query: %v", err)
+		words = nil
+	}
+
+	search := domain.NormalizeText(query.Query)
+
+	var results []interface{}
+	for _, word := range words {
+		if len(results) >= 20 {
+			break
+		}
+
+		text := word.Original + " - " + word.DisplayTranslation()
+		if search != "" && !strings.Contains(domain.NormalizeText(text), search) {
+			continue
+		}
+
+		article := tgbotapi.NewInlineQueryResultArticle(fmt.Sprintf("word-%d", word.ID), text, text)
+		article.Description = word.Example
+		results = append(results, article)
+	}
+
+	answer := tgbotapi.InlineConfig{
+		InlineQueryID: query.ID,
+		Results:       results,
+		CacheTime:     0,
+		IsPersonal:    true,
+	}
+
+	if _, err := h.bot.Request(answer); err != nil {
+		log.Printf("❌ Error answering inline query: %v", err)
+	}
 }
 
 func (h *SimpleHandler) showSessionResults(chatID int64, session *domain.ReviewSession) {
@@ -604,23 +1326,166 @@ func (h *SimpleHandler) sendMessage(chatID int64, text string) {
 	}
 }
 
-func parseWordInput(text string) (original, translation, example string, ok bool) {
-	separators := []string{" | ", " - ", " — ", "|", "-", "—"}
+func (h *SimpleHandler) sendMessageWithKeyboard(chatID int64, text string, keyboard tgbotapi.InlineKeyboardMarkup) {
+	msg := tgbotapi.NewMessage(chatID, text)
+	msg.ParseMode = "Markdown"
+	msg.ReplyMarkup = keyboard
+
+	if _, err := h.bot.Send(msg); err != nil {
+		log.Printf("❌ Error sending message: %v", err)
+	}
+}
+
+func strictnessLabel(strictness string) string {
+	switch strictness {
+	case constants.StrictnessStrict:
+		return "строгая"
+	case constants.StrictnessLenient:
+		return "мягкая"
+	default:
+		return "обычная"
+	}
+}
+
+func dailyGoalStatus(progress *service.DailyProgress) string {
+	if progress.IsGoalAchieved {
+		return "✅ цель выполнена"
+	}
+
+	return fmt.Sprintf("⏳ осталось %d", progress.Remaining)
+}
+
+func gradeKeyboard(wordID int, selected domain.RecallGrade) tgbotapi.InlineKeyboardMarkup {
+	grades := []domain.RecallGrade{domain.GradeAgain, domain.GradeHard, domain.GradeGood, domain.GradeEasy}
+
+	var row []tgbotapi.InlineKeyboardButton
+	for _, grade := range grades {
+		label := gradeLabel(grade)
+		if grade == selected {
+			label = "✓ " + label
+		}
+		row = append(row, callbackButton(label, callbackGrade, wordID, int(grade)))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(row)
+}
+
+func goalKeyboard(current int) tgbotapi.InlineKeyboardMarkup {
+	var row []tgbotapi.InlineKeyboardButton
+	for _, goal := range []int{5, 10, 20, 30, 50} {
+		label := fmt.Sprintf("%d", goal)
+		if goal == current {
+			label = "✓ " + label
+		}
+		row = append(row, callbackButton(label, callbackGoal, goal))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(row)
+}
+
+// choicesKeyboard - по одному варианту ответа в строке, чтобы длинные переводы не обрезались
+func choicesKeyboard(action string, questionID int, choices []string) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i, choice := range choices {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			callbackButton(choice, action, questionID, i),
+		))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func parseReviewMode(arg string) (string, bool) {
+	switch strings.ToLower(arg) {
+	case "typing":
+		return constants.ReviewModeTyping, true
+	case "choice", "mc", "выбор":
+		return constants.ReviewModeChoice, true
+	case "cloze", "пропуски":
+		return constants.ReviewModeCloze, true
+	default:
+		return "", false
+	}
+}
+
+func gradeLabel(grade domain.RecallGrade) string {
+	switch grade {
+	case domain.GradeAgain:
+		return "🔁 Снова"
+	case domain.GradeHard:
+		return "😓 Трудно"
+	case domain.GradeGood:
+		return "🙂 Хорошо"
+	case domain.GradeEasy:
+		return "😎 Легко"
+	default:
+		return "🤖 Авто"
+	}
+}
+
+func directionsLabel(directions string) string {
+	if directions == constants.CardDirectionsBoth {
+		return "в обе стороны"
+	}
+
+	return "слово → перевод"
+}
+
+// parseWordInput разбирает ввод вида "слово - перевод1, перевод2 | пример".
+// Поддерживается и старый формат "слово | перевод | пример".
+func parseWordInput(text string) (original string, translations []string, example string, ok bool) {
+	head, tail, hasExample := strings.Cut(text, "|")
+
+	separators := []string{" - ", " — ", " – "}
+	if !hasExample {
+		separators = append(separators, "-", "—")
+	}
 
 	for _, sep := range separators {
-		if parts := strings.Split(text, sep); len(parts) >= 2 {
-			original = strings.TrimSpace(parts[0])
-			translation = strings.TrimSpace(parts[1])
+		if before, after, found := strings.Cut(head, sep); found {
+			original = strings.TrimSpace(before)
+			translations = splitTranslations(after)
+			example = strings.TrimSpace(tail)
 
-			if len(parts) > 2 {
-				example = strings.TrimSpace(parts[2])
+			if original != "" && len(translations) > 0 {
+				return original, translations, example, true
 			}
+		}
+	}
 
-			if original != "" && translation != "" {
-				return original, translation, example, true
-			}
+	if hasExample {
+		translationPart, examplePart, _ := strings.Cut(tail, "|")
+		original = strings.TrimSpace(head)
+		translations = splitTranslations(translationPart)
+		example = strings.TrimSpace(examplePart)
+
+		if original != "" && len(translations) > 0 {
+			return original, translations, example, true
+		}
+	}
+
+	return "", nil, "", false
+}
+
+// newWordFromInput создаёт слово из разобранной строки parseWordInput
+func newWordFromInput(chatID int64, language, original string, translations []string, example string) *domain.Word {
+	word := domain.NewWord(chatID, original, translations[0], language)
+	word.SetTranslations(translations)
+	if example != "" {
+		word.WithExample(example)
+	}
+
+	return word
+}
+
+// splitTranslations делит строку переводов по запятым и точкам с запятой
+func splitTranslations(text string) []string {
+	var translations []string
+	for _, part := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ';' }) {
+		if part = strings.TrimSpace(part); part != "" {
+			translations = append(translations, part)
 		}
 	}
 
-	return "", "", "", false
+	return translations
 }
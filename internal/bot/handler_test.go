@@ -0,0 +1,49 @@
+package bot
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"ivanSaichkin/language-bot/internal/domain"
+	"ivanSaichkin/language-bot/internal/service"
+)
+
+// fakeSessionService отдаёт каждому пользователю одну незавершённую сессию
+type fakeSessionService struct {
+	service.SessionService
+}
+
+func (fakeSessionService) LoadUserSessions(ctx context.Context, userID int64) ([]*domain.ReviewSession, error) {
+	return []*domain.ReviewSession{domain.NewReviewSession(userID, nil, "")}, nil
+}
+
+// Обновления разных чатов обрабатываются в отдельных горутинах; запускать с -race
+func TestReviewSessionsConcurrentChats(t *testing.T) {
+	h := NewSimpleHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, fakeSessionService{}, nil)
+	ctx := context.Background()
+
+	const chats = 50
+	var wg sync.WaitGroup
+	for chatID := int64(1); chatID <= chats; chatID++ {
+		wg.Add(1)
+		go func(chatID int64) {
+			defer wg.Done()
+
+			unlock := h.lockChat(chatID)
+			defer unlock()
+
+			if _, exists := h.reviewSession(chatID); !exists {
+				h.loadUserSessions(ctx, chatID)
+			}
+			h.setReviewSession(chatID, domain.NewReviewSession(chatID, nil, ""))
+		}(chatID)
+	}
+	wg.Wait()
+
+	for chatID := int64(1); chatID <= chats; chatID++ {
+		if session, exists := h.reviewSession(chatID); !exists || session.UserID != chatID {
+			t.Errorf("chat %d: got session %v, exists %v", chatID, session, exists)
+		}
+	}
+}
@@ -0,0 +1,479 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"ivanSaichkin/language-bot/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Аргументы кнопок подтверждения импорта
+const (
+	importArgConfirm   = "ok"
+	importArgCancel    = "no"
+	importArgField     = "fld"
+	importArgSchedule  = "sched"
+	importArgDuplicate = "dup"
+)
+
+// Куда кнопка сопоставления полей Anki подставляет следующее поле заметки
+const (
+	ankiTargetOriginal    = "o"
+	ankiTargetTranslation = "t"
+	ankiTargetExample     = "e"
+)
+
+// Порядок, в котором кнопка переключает обработку повторов при импорте
+var importDuplicateActions = []string{service.DuplicateSkip, service.DuplicateMerge, service.DuplicateKeep}
+
+// pendingImport - разобранный файл, ждущий подтверждения. Для колоды Anki хранится
+// и сам пакет, чтобы пересобрать слова при смене сопоставления полей.
+type pendingImport struct {
+	preview     *service.ImportPreview
+	anki        *service.AnkiPackage
+	options     service.AnkiImportOptions
+	onDuplicate string
+}
+
+// Сколько строк с ошибками перечислять в сообщениях об импорте
+const importErrorsLimit = 10
+
+var importColumnLabels = map[string]string{
+	service.ImportColumnOriginal:     "слово",
+	service.ImportColumnTranslation:  "перевод",
+	service.ImportColumnExample:      "пример",
+	service.ImportColumnLanguage:     "язык",
+	service.ImportColumnPartOfSpeech: "часть речи",
+	service.ImportColumnTags:         "теги",
+}
+
+var importErrorLabels = map[string]string{
+	service.ImportErrorMissingFields: "нет слова или перевода",
+	service.ImportErrorLanguage:      "неизвестный язык",
+	service.ImportErrorPartOfSpeech:  "неизвестная часть речи",
+	service.ImportErrorDeck:          "слишком длинное название колоды",
+	service.ImportErrorInvalid:       "слово не прошло проверку",
+	service.ImportErrorSave:          "не удалось сохранить",
+}
+
+// handleDocument принимает файл со словами и показывает, что будет импортировано
+func (h *SimpleHandler) handleDocument(ctx context.Context, chatID int64, document *tgbotapi.Document) {
+	switch strings.ToLower(filepath.Ext(document.FileName)) {
+	case ".csv", ".tsv", ".txt":
+	case ".apkg":
+		h.handleAnkiDocument(ctx, chatID, document)
+		return
+	default:
+		h.sendMessage(chatID, "📄 Для импорта пришлите таблицу в формате CSV или TSV (UTF-8) или колоду Anki (.apkg)")
+		return
+	}
+
+	if document.FileSize > service.MaxImportFileSize {
+		h.sendMessage(chatID, fmt.Sprintf("❌ Файл слишком большой, максимум %d КБ", service.MaxImportFileSize>>10))
+		return
+	}
+
+	data, err := h.downloadFile(ctx, document.FileID, service.MaxImportFileSize)
+	if err != nil {
+		log.Printf("❌ Error downloading document: %v", err)
+		h.sendMessage(chatID, "❌ Не удалось загрузить файл")
+		return
+	}
+
+	preview, err := h.importService.ParseTable(ctx, chatID, data)
+	if err != nil {
+		log.Printf("❌ Error parsing import file: %v", err)
+		h.sendMessage(chatID, fmt.Sprintf(`❌ Не удалось разобрать файл
+
+Нужна таблица CSV или TSV в кодировке UTF-8, не больше %d строк.
+Колонки: слово, перевод, пример, язык, часть речи, теги - первые две обязательны, первый тег становится колодой.`, service.MaxImportRows))
+		return
+	}
+
+	if len(preview.Rows) == 0 {
+		h.sendMessage(chatID, "❌ В файле не нашлось слов для импорта\n\n"+importErrorsText(preview.Errors))
+		return
+	}
+
+	pending := &pendingImport{preview: preview, onDuplicate: service.DuplicateSkip}
+	h.stateMu.Lock()
+	h.imports[chatID] = pending
+	h.stateMu.Unlock()
+	h.sendMessageWithKeyboard(chatID, pendingImportText(pending), importKeyboard(pending))
+}
+
+// handleAnkiDocument разбирает колоду Anki и предлагает проверить сопоставление полей
+func (h *SimpleHandler) handleAnkiDocument(ctx context.Context, chatID int64, document *tgbotapi.Document) {
+	if document.FileSize > service.MaxAnkiFileSize {
+		h.sendMessage(chatID, fmt.Sprintf("❌ Файл слишком большой, максимум %d МБ", service.MaxAnkiFileSize>>20))
+		return
+	}
+
+	data, err := h.downloadFile(ctx, document.FileID, service.MaxAnkiFileSize)
+	if err != nil {
+		log.Printf("❌ Error downloading document: %v", err)
+		h.sendMessage(chatID, "❌ Не удалось загрузить файл")
+		return
+	}
+
+	pkg, err := h.importService.ParseAnki(data)
+	if err != nil {
+		log.Printf("❌ Error parsing anki package: %v", err)
+		h.sendMessage(chatID, fmt.Sprintf(`❌ Не удалось прочитать колоду Anki
+
+Экспортируйте колоду в формате «Anki Deck Package (.apkg)» с включённой совместимостью со старыми версиями. Не больше %d заметок.`, service.MaxImportRows))
+		return
+	}
+
+	pending := &pendingImport{anki: pkg, onDuplicate: service.DuplicateSkip}
+	if pending.preview, err = h.importService.PreviewAnki(ctx, chatID, pkg, pending.options); err != nil {
+		log.Printf("❌ Error building anki preview: %v", err)
+		h.sendMessage(chatID, "❌ Не удалось прочитать колоду Anki")
+		return
+	}
+
+	h.stateMu.Lock()
+	h.imports[chatID] = pending
+	h.stateMu.Unlock()
+	h.sendMessageWithKeyboard(chatID, pendingImportText(pending), importKeyboard(pending))
+}
+
+func (h *SimpleHandler) handleImportCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+	messageID := query.Message.MessageID
+
+	h.stateMu.Lock()
+	pending, exists := h.imports[chatID]
+	h.stateMu.Unlock()
+	if !exists {
+		h.answerCallback(query.ID, "Импорт уже завершён или отменён")
+		h.removeKeyboard(chatID, messageID)
+		return
+	}
+
+	switch data.Arg(0) {
+	case importArgField, importArgSchedule:
+		if pending.anki == nil {
+			h.answerCallback(query.ID, "")
+			return
+		}
+
+		if data.Arg(0) == importArgField {
+			index, err := data.Int(1)
+			if err != nil || index < 0 || index >= len(pending.anki.Models) {
+				h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+				return
+			}
+			cycleAnkiField(pending.anki.Models[index], data.Arg(2))
+		} else {
+			pending.options.KeepSchedule = !pending.options.KeepSchedule
+		}
+
+		preview, err := h.importService.PreviewAnki(ctx, chatID, pending.anki, pending.options)
+		if err != nil {
+			log.Printf("❌ Error building anki preview: %v", err)
+			h.answerCallback(query.ID, "❌ Не удалось пересобрать слова")
+			return
+		}
+		pending.preview = preview
+
+		h.answerCallback(query.ID, "")
+		keyboard := importKeyboard(pending)
+		h.editMessage(chatID, messageID, pendingImportText(pending), &keyboard)
+		return
+
+	case importArgDuplicate:
+		pending.onDuplicate = nextOption(importDuplicateActions, pending.onDuplicate)
+
+		h.answerCallback(query.ID, "")
+		keyboard := importKeyboard(pending)
+		h.editMessage(chatID, messageID, pendingImportText(pending), &keyboard)
+		return
+
+	case importArgConfirm:
+		h.stateMu.Lock()
+		delete(h.imports, chatID)
+		h.stateMu.Unlock()
+
+	default:
+		h.stateMu.Lock()
+		delete(h.imports, chatID)
+		h.stateMu.Unlock()
+		h.answerCallback(query.ID, "")
+		h.editMessage(chatID, messageID, "❌ Импорт отменён", nil)
+		return
+	}
+
+	preview := pending.preview
+
+	h.answerCallback(query.ID, "⏳ Импортирую...")
+	h.editMessage(chatID, messageID, fmt.Sprintf("⏳ Импортирую %d слов...", len(preview.Rows)), nil)
+
+	result, err := h.importService.Import(ctx, chatID, preview, pending.onDuplicate)
+	if err != nil {
+		log.Printf("❌ Error importing words: %v", err)
+		h.editMessage(chatID, messageID, "❌ Не удалось импортировать слова", nil)
+		return
+	}
+
+	response := fmt.Sprintf("📥 *Импорт завершён*\n\n• Добавлено: %d", result.Added)
+	if result.Merged > 0 {
+		response += fmt.Sprintf("\n• Объединено со словами словаря: %d", result.Merged)
+	}
+	response += fmt.Sprintf("\n• Пропущено повторов: %d", result.Duplicates)
+	if len(result.Errors) > 0 {
+		response += "\n\n" + importErrorsText(result.Errors)
+	}
+	h.editMessage(chatID, messageID, response, nil)
+}
+
+// downloadFile скачивает файл, присланный пользователем, не больше maxSize байт
+func (h *SimpleHandler) downloadFile(ctx context.Context, fileID string, maxSize int) ([]byte, error) {
+	url, err := h.bot.GetFileDirectURL(fileID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file url: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download file: status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxSize)+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	if len(data) > maxSize {
+		return nil, fmt.Errorf("file is larger than %d bytes", maxSize)
+	}
+
+	return data, nil
+}
+
+// importKeyboard - подтверждение импорта; для Anki ещё настройки сопоставления полей
+func importKeyboard(pending *pendingImport) tgbotapi.InlineKeyboardMarkup {
+	rows := [][]tgbotapi.InlineKeyboardButton{
+		tgbotapi.NewInlineKeyboardRow(
+			callbackButton(fmt.Sprintf("✅ Импортировать %d", len(pending.preview.Rows)), callbackImport, importArgConfirm),
+			callbackButton("❌ Отмена", callbackImport, importArgCancel),
+		),
+	}
+
+	if pending.preview.Duplicates > 0 {
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			callbackButton("♻️ Повторы: "+duplicateActionLabels[pending.onDuplicate], callbackImport, importArgDuplicate),
+		))
+	}
+
+	if pending.anki != nil {
+		schedule := "📅 Перенести расписание: нет"
+		if pending.options.KeepSchedule {
+			schedule = "📅 Перенести расписание: да"
+		}
+
+		rows = append(rows, ankiMappingRows(pending.anki)...)
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(
+			callbackButton(schedule, callbackImport, importArgSchedule),
+		))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// ankiMappingRows - по кнопке на слово, перевод и пример каждой модели с заметками;
+// нажатие подставляет следующее поле заметки
+func ankiMappingRows(pkg *service.AnkiPackage) [][]tgbotapi.InlineKeyboardButton {
+	used := usedAnkiModels(pkg)
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, index := range used {
+		model := pkg.Models[index]
+		prefix := ""
+		if len(used) > 1 {
+			prefix = model.Name + " · "
+		}
+
+		rows = append(rows,
+			tgbotapi.NewInlineKeyboardRow(
+				callbackButton(prefix+"Слово: "+ankiFieldName(model, model.Mapping.Original),
+					callbackImport, importArgField, index, ankiTargetOriginal),
+				callbackButton(prefix+"Перевод: "+ankiFieldName(model, model.Mapping.Translation),
+					callbackImport, importArgField, index, ankiTargetTranslation),
+			),
+			tgbotapi.NewInlineKeyboardRow(
+				callbackButton(prefix+"Пример: "+ankiFieldName(model, model.Mapping.Example),
+					callbackImport, importArgField, index, ankiTargetExample),
+			),
+		)
+	}
+
+	return rows
+}
+
+// usedAnkiModels возвращает номера моделей, по которым в колоде есть заметки
+func usedAnkiModels(pkg *service.AnkiPackage) []int {
+	used := make(map[int64]bool)
+	for _, note := range pkg.Notes {
+		used[note.ModelID] = true
+	}
+
+	var indexes []int
+	for i, model := range pkg.Models {
+		if used[model.ID] {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes
+}
+
+// cycleAnkiField переключает поле модели на следующее; пример можно и не импортировать
+func cycleAnkiField(model *service.AnkiModel, target string) {
+	next := func(current int, optional bool) int {
+		if current+1 < len(model.Fields) {
+			return current + 1
+		}
+		if optional {
+			return -1
+		}
+		return 0
+	}
+
+	switch target {
+	case ankiTargetOriginal:
+		model.Mapping.Original = next(model.Mapping.Original, false)
+	case ankiTargetTranslation:
+		model.Mapping.Translation = next(model.Mapping.Translation, false)
+	case ankiTargetExample:
+		model.Mapping.Example = next(model.Mapping.Example, true)
+	}
+}
+
+// ankiPreviewText показывает, какие поля каких моделей станут словом, переводом и примером
+func ankiPreviewText(pending *pendingImport) string {
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("📦 *Импорт колоды Anki*\n\nЗаметок: %d\n\n*Сопоставление полей:*\n", len(pending.anki.Notes)))
+	for _, index := range usedAnkiModels(pending.anki) {
+		model := pending.anki.Models[index]
+		mapping := model.Mapping
+		text.WriteString(fmt.Sprintf("• %s: слово ← %s, перевод ← %s, пример ← %s\n", model.Name,
+			ankiFieldName(model, mapping.Original), ankiFieldName(model, mapping.Translation), ankiFieldName(model, mapping.Example)))
+	}
+
+	text.WriteString("_Кнопки под сообщением переключают поле заметки для слова, перевода и примера_\n")
+	if pending.options.KeepSchedule {
+		text.WriteString("\n📅 Интервалы, лёгкость и даты повторений будут перенесены из Anki\n")
+	} else {
+		text.WriteString("\n📅 Слова начнут изучаться заново\n")
+	}
+
+	text.WriteString("\n" + importPreviewText(pending.preview, pending.onDuplicate))
+	return text.String()
+}
+
+// pendingImportText - предпросмотр импорта таблицы или колоды Anki
+func pendingImportText(pending *pendingImport) string {
+	if pending.anki != nil {
+		return ankiPreviewText(pending)
+	}
+
+	return importPreviewText(pending.preview, pending.onDuplicate)
+}
+
+func ankiFieldName(model *service.AnkiModel, index int) string {
+	if index < 0 || index >= len(model.Fields) {
+		return "—"
+	}
+
+	return model.Fields[index]
+}
+
+func importPreviewText(preview *service.ImportPreview, onDuplicate string) string {
+	columns := make([]string, len(preview.Columns))
+	for i, column := range preview.Columns {
+		columns[i] = importColumnLabels[column]
+	}
+
+	header := "без заголовка"
+	if preview.HasHeader {
+		header = "по заголовку"
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("📄 *Импорт из %s*\n\n", preview.Format))
+	if len(columns) > 0 {
+		text.WriteString(fmt.Sprintf("Колонки (%s): %s\n\n", header, strings.Join(columns, ", ")))
+	}
+	text.WriteString(fmt.Sprintf("• Готово к импорту: %d\n• Строк с ошибками: %d\n\n", len(preview.Rows), len(preview.Errors)))
+	if len(preview.Decks) > 0 {
+		text.WriteString(fmt.Sprintf("📂 Колоды из тегов: %s - недостающие будут созданы\n\n", strings.Join(preview.Decks, ", ")))
+	}
+
+	for i, row := range preview.Rows {
+		if i >= 5 {
+			text.WriteString(fmt.Sprintf("... и ещё %d\n", len(preview.Rows)-i))
+			break
+		}
+		text.WriteString(fmt.Sprintf("• *%s* - %s\n", row.Word.Original, row.Word.DisplayTranslation()))
+	}
+
+	if len(preview.Errors) > 0 {
+		text.WriteString("\n" + importErrorsText(preview.Errors))
+	}
+
+	if preview.Duplicates == 0 {
+		return text.String()
+	}
+
+	text.WriteString(fmt.Sprintf("\n♻️ Уже есть в словаре или очень похожи: %d. ", preview.Duplicates))
+	switch onDuplicate {
+	case service.DuplicateMerge:
+		text.WriteString("Их переводы будут добавлены к словам словаря.")
+	case service.DuplicateKeep:
+		text.WriteString("Они будут добавлены отдельными словами.")
+	default:
+		text.WriteString("Они будут пропущены.")
+	}
+
+	return text.String()
+}
+
+func importErrorsText(errors []service.ImportRowError) string {
+	if len(errors) == 0 {
+		return ""
+	}
+
+	var text strings.Builder
+	text.WriteString("❌ *Строки с ошибками:*\n")
+	for i, rowError := range errors {
+		if i >= importErrorsLimit {
+			text.WriteString(fmt.Sprintf("... и ещё %d\n", len(errors)-i))
+			break
+		}
+		text.WriteString(fmt.Sprintf("• строка %d: %s\n", rowError.Line, importErrorLabels[rowError.Reason]))
+	}
+
+	return text.String()
+}
@@ -0,0 +1,132 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"ivanSaichkin/language-bot/internal/constants"
+	"ivanSaichkin/language-bot/internal/domain"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleLanguageCommand: /language [код или название] - выбрать изучаемый язык.
+// Без аргументов показывает клавиатуру и ждёт выбора кнопкой или текстом.
+func (h *SimpleHandler) handleLanguageCommand(ctx context.Context, chatID int64, args string) {
+	if input := strings.TrimSpace(args); input != "" {
+		h.handleLanguageInput(ctx, chatID, input)
+		return
+	}
+
+	user, err := h.userService.GetUser(ctx, chatID)
+	if err != nil {
+		h.sendMessage(chatID, "❌ Не удалось получить информацию о пользователе")
+		return
+	}
+
+	if err := h.userService.SetUserState(ctx, chatID, string(constants.StateAwaitingLanguage)); err != nil {
+		h.sendMessage(chatID, "❌ Ошибка при изменении состояния")
+		return
+	}
+
+	response := fmt.Sprintf(`🌍 *Изучаемый язык:* %s
+
+Новые слова добавляются на этом языке, а /words, /review и /stats показывают только его слова.
+Выберите язык кнопкой или напишите код: %s`, domain.LanguageLabel(user.StudyLanguage), domain.LanguageCodes())
+
+	h.sendMessageWithKeyboard(chatID, response, languageKeyboard(user.StudyLanguage))
+}
+
+// handleLanguageInput разбирает язык, введённый текстом в ответ на /language
+func (h *SimpleHandler) handleLanguageInput(ctx context.Context, chatID int64, input string) {
+	language, ok := domain.FindLanguage(input)
+	if !ok {
+		h.sendMessage(chatID, fmt.Sprintf("❌ Неизвестный язык «%s». Доступные коды: %s", input, domain.LanguageCodes()))
+		return
+	}
+
+	if err := h.setStudyLanguage(ctx, chatID, language.Code); err != nil {
+		h.sendMessage(chatID, "❌ Не удалось выбрать язык")
+		return
+	}
+
+	h.sendMessage(chatID, languageSelectedText(language))
+}
+
+func (h *SimpleHandler) handleLanguageCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+
+	language, ok := domain.FindLanguage(data.Arg(0))
+	if !ok {
+		h.answerCallback(query.ID, "❌ Неизвестный язык")
+		return
+	}
+
+	if err := h.setStudyLanguage(ctx, chatID, language.Code); err != nil {
+		h.answerCallback(query.ID, "❌ Не удалось выбрать язык")
+		return
+	}
+
+	h.answerCallback(query.ID, language.Label())
+	h.editMessage(chatID, query.Message.MessageID, languageSelectedText(language), nil)
+}
+
+// setStudyLanguage сохраняет язык и выходит из ожидания выбора
+func (h *SimpleHandler) setStudyLanguage(ctx context.Context, chatID int64, code string) error {
+	if err := h.userService.SetStudyLanguage(ctx, chatID, code); err != nil {
+		log.Printf("❌ Error setting study language: %v", err)
+		return err
+	}
+
+	if state, err := h.userService.GetUserState(ctx, chatID); err == nil && state == string(constants.StateAwaitingLanguage) {
+		if err := h.userService.SetUserState(ctx, chatID, ""); err != nil {
+			log.Printf("⚠️ Failed to reset user state: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// wordFilter выбирает слова колоды, если она указана, иначе - слова изучаемого языка
+func (h *SimpleHandler) wordFilter(ctx context.Context, chatID int64, deckID int) domain.WordFilter {
+	if deckID != 0 {
+		return domain.WordFilter{DeckID: deckID}
+	}
+
+	user, err := h.userService.GetUser(ctx, chatID)
+	if err != nil {
+		log.Printf("⚠️ Failed to get study language: %v", err)
+		return domain.WordFilter{}
+	}
+
+	return domain.WordFilter{Language: user.StudyLanguage}
+}
+
+func languageSelectedText(language domain.Language) string {
+	return fmt.Sprintf("✅ Изучаемый язык: *%s*\nДобавляйте слова: /add", language.Label())
+}
+
+// languageKeyboard - по два языка в строке, текущий отмечен галочкой
+func languageKeyboard(current string) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+	for _, language := range domain.SupportedLanguages {
+		label := language.Label()
+		if language.Code == current {
+			label = "✓ " + label
+		}
+
+		row = append(row, callbackButton(label, callbackLanguage, language.Code))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
@@ -0,0 +1,196 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"ivanSaichkin/language-bot/internal/domain"
+	"ivanSaichkin/language-bot/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Аргументы кнопок игры: выбор слова, выбор перевода и новая игра
+const (
+	matchArgWord        = "w"
+	matchArgTranslation = "t"
+	matchArgNew         = "new"
+)
+
+// activeMatch связывает игру с сообщением, чтобы кнопки старых игр не влияли на текущую
+type activeMatch struct {
+	game      *domain.MatchGame
+	messageID int
+}
+
+func (h *SimpleHandler) handleMatchCommand(ctx context.Context, chatID int64) {
+	game, err := h.matchService.StartMatch(ctx, chatID)
+	if err != nil {
+		log.Printf("❌ Error starting match game: %v", err)
+		keyboard := tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(callbackButton("➕ Добавить слово", callbackAddWord)),
+		)
+		h.sendMessageWithKeyboard(chatID,
+			fmt.Sprintf("❌ Для игры нужно хотя бы %d слова с разными переводами", service.MinMatchPairs), keyboard)
+		return
+	}
+
+	msg := tgbotapi.NewMessage(chatID, matchGameText(game, ""))
+	msg.ParseMode = "Markdown"
+	msg.ReplyMarkup = matchKeyboard(game)
+
+	sent, err := h.bot.Send(msg)
+	if err != nil {
+		log.Printf("❌ Error sending message: %v", err)
+		return
+	}
+
+	h.stateMu.Lock()
+	h.matches[chatID] = &activeMatch{game: game, messageID: sent.MessageID}
+	h.stateMu.Unlock()
+}
+
+func (h *SimpleHandler) handleMatchCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+	messageID := query.Message.MessageID
+
+	if data.Arg(0) == matchArgNew {
+		h.answerCallback(query.ID, "")
+		h.removeKeyboard(chatID, messageID)
+		h.handleMatchCommand(ctx, chatID)
+		return
+	}
+
+	index, err := data.Int(1)
+	if err != nil {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return
+	}
+
+	h.stateMu.Lock()
+	active, exists := h.matches[chatID]
+	h.stateMu.Unlock()
+	if !exists || active.messageID != messageID || active.game.IsCompleted {
+		h.answerCallback(query.ID, "Эта игра уже завершена")
+		return
+	}
+	game := active.game
+
+	switch data.Arg(0) {
+	case matchArgWord:
+		if !game.Select(index) {
+			h.answerCallback(query.ID, "")
+			return
+		}
+
+		h.answerCallback(query.ID, "")
+		keyboard := matchKeyboard(game)
+		h.editMessage(chatID, messageID, matchGameText(game, ""), &keyboard)
+
+	case matchArgTranslation:
+		if !game.HasSelection() {
+			h.answerCallback(query.ID, "👈 Сначала выберите слово слева")
+			return
+		}
+
+		// Повторное нажатие на уже найденный перевод игнорируем
+		if index < 0 || index >= len(game.Order) || game.IsTranslationMatched(index) {
+			h.answerCallback(query.ID, "")
+			return
+		}
+
+		result, err := h.matchService.MatchPair(ctx, game, index)
+		if err != nil {
+			log.Printf("❌ Error matching pair: %v", err)
+			h.answerCallback(query.ID, "❌ Ошибка при обработке ответа")
+			return
+		}
+
+		if result.IsComplete {
+			h.answerCallback(query.ID, "🎉 Все пары найдены!")
+			h.stateMu.Lock()
+			delete(h.matches, chatID)
+			h.stateMu.Unlock()
+			h.showMatchResults(chatID, messageID, game)
+			return
+		}
+
+		feedback := fmt.Sprintf("✅ %s - верно!", result.Original)
+		if !result.IsCorrect {
+			h.answerCallback(query.ID, "❌ Не та пара")
+			feedback = fmt.Sprintf("❌ %s - не та пара, попробуйте ещё", result.Original)
+		} else {
+			h.answerCallback(query.ID, "✅")
+		}
+
+		keyboard := matchKeyboard(game)
+		h.editMessage(chatID, messageID, matchGameText(game, feedback), &keyboard)
+
+	default:
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+	}
+}
+
+func (h *SimpleHandler) showMatchResults(chatID int64, messageID int, game *domain.MatchGame) {
+	response := fmt.Sprintf(`🏁 *Все пары найдены!*
+
+• Пар: %d
+• Ошибок: %d
+• Время: %.0f сек`,
+		len(game.Words),
+		game.TotalMistakes(),
+		game.GetDuration().Seconds(),
+	)
+
+	var missed []string
+	for i, word := range game.Words {
+		if game.Mistakes[i] > 0 {
+			missed = append(missed, fmt.Sprintf("• *%s* - %s", word.Original, word.Translation))
+		}
+	}
+	if len(missed) > 0 {
+		response += "\n\n❌ *Стоит повторить:*\n" + strings.Join(missed, "\n")
+	} else {
+		response += "\n\n🎉 Без единой ошибки!"
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(callbackButton("🧩 Ещё раз", callbackMatch, matchArgNew)),
+	)
+	h.editMessage(chatID, messageID, response, &keyboard)
+}
+
+func matchGameText(game *domain.MatchGame, feedback string) string {
+	text := fmt.Sprintf("🧩 *Найдите пары*\nВыберите слово слева, затем его перевод справа.\n\nНайдено: %d/%d · ошибок: %d",
+		game.MatchedCount(), len(game.Words), game.TotalMistakes())
+	if feedback != "" {
+		text += "\n" + feedback
+	}
+
+	return text
+}
+
+// matchKeyboard - в каждой строке слово и перевод; найденные пары остаются на месте, но не нажимаются
+func matchKeyboard(game *domain.MatchGame) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for i, word := range game.Words {
+		wordButton := callbackButton(word.Original, callbackMatch, matchArgWord, i)
+		switch {
+		case game.Matched[i]:
+			wordButton = callbackButton("✅ "+word.Original, callbackNoop)
+		case game.Selected == i:
+			wordButton = callbackButton("👉 "+word.Original, callbackMatch, matchArgWord, i)
+		}
+
+		translationButton := callbackButton(game.TranslationAt(i), callbackMatch, matchArgTranslation, i)
+		if game.IsTranslationMatched(i) {
+			translationButton = callbackButton("✅ "+game.TranslationAt(i), callbackNoop)
+		}
+
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(wordButton, translationButton))
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
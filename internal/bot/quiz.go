@@ -0,0 +1,293 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"ivanSaichkin/language-bot/internal/constants"
+	"ivanSaichkin/language-bot/internal/domain"
+	"ivanSaichkin/language-bot/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// handleTestCommand запускает тест: /test [число вопросов] [язык] [колода] или /test stop.
+// Всё, что идёт после числа и кода языка, - название или номер колоды.
+func (h *SimpleHandler) handleTestCommand(ctx context.Context, chatID int64, args string) {
+	size, filter := 0, domain.WordFilter{}
+	var deckName []string
+	for _, arg := range strings.Fields(args) {
+		lower := strings.ToLower(arg)
+		if lower == "stop" || lower == "стоп" {
+			h.stopQuiz(ctx, chatID)
+			return
+		}
+
+		if len(deckName) == 0 {
+			if n, err := strconv.Atoi(arg); err == nil && size == 0 {
+				size = n
+				continue
+			}
+			if found, ok := domain.FindLanguage(lower); ok && filter.Language == "" {
+				filter.Language = found.Code
+				continue
+			}
+		}
+
+		deckName = append(deckName, arg)
+	}
+
+	if len(deckName) > 0 {
+		deck := h.findDeckOrReport(ctx, chatID, strings.Join(deckName, " "))
+		if deck == nil {
+			return
+		}
+		filter.DeckID = deck.ID
+	}
+
+	if session, exists := h.reviewSession(chatID); exists && !session.IsCompleted {
+		h.sendMessage(chatID, "🔁 Сначала завершите сессию повторения")
+		return
+	}
+
+	h.stateMu.Lock()
+	quiz, exists := h.quizzes[chatID]
+	h.stateMu.Unlock()
+	if exists && !quiz.IsCompleted {
+		h.sendMessage(chatID, "🧪 Тест уже идёт. Ответьте на вопрос или прервите тест: /test stop")
+		return
+	}
+
+	quiz, err := h.quizService.StartQuiz(ctx, chatID, size, filter)
+	if err != nil {
+		log.Printf("❌ Error starting quiz: %v", err)
+		keyboard := tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(callbackButton("➕ Добавить слово", callbackAddWord)),
+		)
+		h.sendMessageWithKeyboard(chatID, "❌ Не удалось начать тест: нет подходящих слов", keyboard)
+		return
+	}
+
+	if err := h.userService.SetUserState(ctx, chatID, string(constants.StateInTest)); err != nil {
+		log.Printf("⚠️ Failed to set user state: %v", err)
+	}
+
+	h.stateMu.Lock()
+	h.quizzes[chatID] = quiz
+	h.stateMu.Unlock()
+	h.sendMessage(chatID, fmt.Sprintf("🧪 *Тест: %d вопросов*\nСлова выбраны случайно, результаты не влияют на расписание повторений.",
+		len(quiz.Questions)))
+	h.sendQuizQuestion(chatID, quiz)
+}
+
+func (h *SimpleHandler) sendQuizQuestion(chatID int64, quiz *domain.Quiz) {
+	question := quiz.CurrentQuestion()
+	if question == nil {
+		return
+	}
+
+	word := question.Word
+	current, total := quiz.GetProgress()
+
+	hint := "Напишите перевод"
+	if word.IsReverse() {
+		hint = "Напишите слово на языке оригинала"
+	}
+	if question.HasChoices() {
+		hint = "Выберите перевод"
+	}
+
+	text := fmt.Sprintf("🧪 Вопрос %d/%d\n\n*%s*\n\n_%s_", current, total, word.Prompt(), hint)
+
+	if !question.HasChoices() {
+		h.sendMessage(chatID, text)
+		return
+	}
+
+	h.sendMessageWithKeyboard(chatID, text, choicesKeyboard(callbackQuizChoice, quiz.CurrentIndex, question.Choices))
+}
+
+func (h *SimpleHandler) handleQuizAnswer(ctx context.Context, chatID int64, answer string) {
+	h.stateMu.Lock()
+	quiz, exists := h.quizzes[chatID]
+	h.stateMu.Unlock()
+	if !exists || quiz.IsCompleted {
+		// Тесты хранятся только в памяти и не переживают перезапуск бота
+		if err := h.userService.SetUserState(ctx, chatID, ""); err != nil {
+			log.Printf("⚠️ Failed to reset user state: %v", err)
+		}
+		h.sendMessage(chatID, "🧪 Тест прерван. Начните новый: /test")
+		return
+	}
+
+	question := quiz.CurrentQuestion()
+	if question != nil && question.HasChoices() {
+		h.sendMessage(chatID, "👆 Выберите вариант ответа кнопкой под вопросом")
+		return
+	}
+
+	result, err := h.quizService.AnswerQuestion(ctx, quiz, answer)
+	if err != nil {
+		log.Printf("❌ Error processing quiz answer: %v", err)
+		h.sendMessage(chatID, "❌ Ошибка при обработке ответа")
+		return
+	}
+
+	h.sendMessage(chatID, quizAnswerText(result, answer))
+	h.continueQuiz(ctx, chatID, quiz)
+}
+
+func (h *SimpleHandler) handleQuizChoiceCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+
+	questionIndex, err := data.Int(0)
+	if err != nil {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return
+	}
+	index, err := data.Int(1)
+	if err != nil {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return
+	}
+
+	h.stateMu.Lock()
+	quiz, exists := h.quizzes[chatID]
+	h.stateMu.Unlock()
+	if !exists || quiz.IsCompleted {
+		h.answerCallback(query.ID, "Тест уже завершён")
+		return
+	}
+
+	question := quiz.CurrentQuestion()
+	if question == nil || quiz.CurrentIndex != questionIndex || index < 0 || index >= len(question.Choices) {
+		h.answerCallback(query.ID, "Этот вопрос уже пройден")
+		return
+	}
+
+	choice := question.Choices[index]
+	result, err := h.quizService.AnswerQuestion(ctx, quiz, choice)
+	if err != nil {
+		log.Printf("❌ Error processing quiz choice: %v", err)
+		h.answerCallback(query.ID, "❌ Ошибка при обработке ответа")
+		return
+	}
+
+	if result.IsCorrect {
+		h.answerCallback(query.ID, "✅ Верно!")
+	} else {
+		h.answerCallback(query.ID, "❌ Неверно")
+	}
+	h.editMessage(chatID, query.Message.MessageID, quizAnswerText(result, choice), nil)
+
+	h.continueQuiz(ctx, chatID, quiz)
+}
+
+func (h *SimpleHandler) continueQuiz(ctx context.Context, chatID int64, quiz *domain.Quiz) {
+	if quiz.IsCompleted {
+		h.showQuizResults(ctx, chatID, quiz)
+		return
+	}
+
+	h.sendQuizQuestion(chatID, quiz)
+}
+
+func (h *SimpleHandler) stopQuiz(ctx context.Context, chatID int64) {
+	h.stateMu.Lock()
+	quiz, exists := h.quizzes[chatID]
+	h.stateMu.Unlock()
+	if !exists || quiz.IsCompleted {
+		h.sendMessage(chatID, "🧪 Нет активного теста")
+		return
+	}
+
+	quiz.Complete()
+	h.showQuizResults(ctx, chatID, quiz)
+}
+
+// showQuizResults показывает итоги и список ошибок. Тест остаётся в памяти,
+// пока пользователь не решит, возвращать ли ошибки в повторение.
+func (h *SimpleHandler) showQuizResults(ctx context.Context, chatID int64, quiz *domain.Quiz) {
+	if err := h.userService.SetUserState(ctx, chatID, ""); err != nil {
+		log.Printf("⚠️ Failed to reset user state: %v", err)
+	}
+
+	response := fmt.Sprintf(`🏁 *Тест завершён!*
+
+📊 Результаты:
+• Правильных ответов: %d/%d
+• Результат: %.0f%%
+• Время: %.0f сек`,
+		quiz.Correct,
+		quiz.Answered(),
+		quiz.GetScore(),
+		quiz.EndTime.Sub(quiz.StartTime).Seconds(),
+	)
+
+	missed := quiz.MissedQuestions()
+	if len(missed) == 0 {
+		h.stateMu.Lock()
+		delete(h.quizzes, chatID)
+		h.stateMu.Unlock()
+		if quiz.Answered() > 0 {
+			response += "\n\n🎉 Ни одной ошибки!"
+		}
+		h.sendMessage(chatID, response)
+		return
+	}
+
+	response += "\n\n❌ *Ошибки:*"
+	for _, question := range missed {
+		response += fmt.Sprintf("\n• *%s* - %s", question.Word.Prompt(), question.Word.DisplayAnswer())
+		if question.Answer != "" {
+			response += fmt.Sprintf(" (ваш ответ: %s)", question.Answer)
+		}
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(callbackButton("🔁 Вернуть ошибки в повторение", callbackQuizReset)),
+	)
+	h.sendMessageWithKeyboard(chatID, response, keyboard)
+}
+
+func (h *SimpleHandler) handleQuizResetCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+
+	h.stateMu.Lock()
+	quiz, exists := h.quizzes[chatID]
+	h.stateMu.Unlock()
+	if !exists || !quiz.IsCompleted {
+		h.answerCallback(query.ID, "Результаты теста уже недоступны")
+		h.removeKeyboard(chatID, query.Message.MessageID)
+		return
+	}
+
+	reset, err := h.quizService.ResetMissedWords(ctx, quiz)
+	if err != nil {
+		log.Printf("❌ Error resetting missed words: %v", err)
+		h.answerCallback(query.ID, "❌ Не удалось вернуть слова")
+		return
+	}
+
+	h.stateMu.Lock()
+	delete(h.quizzes, chatID)
+	h.stateMu.Unlock()
+	h.answerCallback(query.ID, "✅ Готово")
+	h.removeKeyboard(chatID, query.Message.MessageID)
+	h.sendMessage(chatID, fmt.Sprintf("🔁 Слов возвращено в повторение: %d. Начните сессию: /review", reset))
+}
+
+func quizAnswerText(result *service.QuizAnswerResult, answer string) string {
+	switch {
+	case result.Match == service.MatchClose:
+		return fmt.Sprintf("✅ *%s* - %s\n✏️ Почти верно, правильное написание: *%s*",
+			result.Prompt, answer, result.MatchedTranslation)
+	case result.IsCorrect:
+		return fmt.Sprintf("✅ *%s* - %s", result.Prompt, result.CorrectAnswer)
+	default:
+		return fmt.Sprintf("❌ *%s* - %s\nПравильный ответ: *%s*", result.Prompt, answer, result.CorrectAnswer)
+	}
+}
@@ -0,0 +1,197 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"ivanSaichkin/language-bot/internal/domain"
+	"ivanSaichkin/language-bot/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// activeSprint связывает спринт с сообщением, в котором по таймеру показываются итоги
+type activeSprint struct {
+	sprint    *domain.Sprint
+	messageID int
+}
+
+// activeSprintFor возвращает текущий спринт чата
+func (h *SimpleHandler) activeSprintFor(chatID int64) (*activeSprint, bool) {
+	h.sprintsMu.Lock()
+	defer h.sprintsMu.Unlock()
+
+	active, exists := h.sprints[chatID]
+	return active, exists
+}
+
+func (h *SimpleHandler) handleSprintCommand(ctx context.Context, chatID int64) {
+	if active, exists := h.activeSprintFor(chatID); exists {
+		if !active.sprint.IsExpired() {
+			h.sendMessage(chatID, fmt.Sprintf("🏃 Спринт уже идёт, осталось %.0f сек", active.sprint.TimeLeft().Seconds()))
+			return
+		}
+
+		// Таймер истёкшего спринта мог ещё не сработать - итоги показываются до начала нового
+		h.finishSprint(ctx, chatID, active.messageID, active.sprint)
+	}
+
+	sprint, err := h.sprintService.StartSprint(ctx, chatID)
+	if err != nil {
+		log.Printf("❌ Error starting sprint: %v", err)
+		keyboard := tgbotapi.NewInlineKeyboardMarkup(
+			tgbotapi.NewInlineKeyboardRow(callbackButton("➕ Добавить слово", callbackAddWord)),
+		)
+		h.sendMessageWithKeyboard(chatID,
+			fmt.Sprintf("❌ Для спринта нужно хотя бы %d слова в словаре", service.MinSprintWords), keyboard)
+		return
+	}
+
+	msg := tgbotapi.NewMessage(chatID, sprintPairText(sprint, ""))
+	msg.ParseMode = "Markdown"
+	msg.ReplyMarkup = sprintKeyboard(sprint)
+
+	sent, err := h.bot.Send(msg)
+	if err != nil {
+		log.Printf("❌ Error sending message: %v", err)
+		return
+	}
+
+	messageID := sent.MessageID
+	h.sprintsMu.Lock()
+	h.sprints[chatID] = &activeSprint{sprint: sprint, messageID: messageID}
+	h.sprintsMu.Unlock()
+
+	// По истечении минуты итоги показываются, даже если пользователь перестал отвечать.
+	// Таймер ждёт блокировку чата, чтобы не завершить спринт посреди обработки ответа.
+	time.AfterFunc(sprint.TimeLeft(), func() {
+		unlock := h.lockChat(chatID)
+		defer unlock()
+
+		h.finishSprint(context.Background(), chatID, messageID, sprint)
+	})
+}
+
+func (h *SimpleHandler) handleSprintCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+
+	if data.Arg(0) == "new" {
+		h.answerCallback(query.ID, "")
+		h.removeKeyboard(chatID, query.Message.MessageID)
+		h.handleSprintCommand(ctx, chatID)
+		return
+	}
+
+	pairIndex, err := data.Int(0)
+	if err != nil {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return
+	}
+	answer, err := data.Int(1)
+	if err != nil {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return
+	}
+
+	active, exists := h.activeSprintFor(chatID)
+	if !exists || active.sprint.IsCompleted {
+		h.answerCallback(query.ID, "Спринт уже завершён")
+		return
+	}
+	sprint := active.sprint
+
+	// Повторное нажатие на уже отвеченную пару игнорируем
+	if pairIndex != sprint.Answered {
+		h.answerCallback(query.ID, "")
+		return
+	}
+
+	result, err := h.sprintService.AnswerPair(ctx, sprint, answer == 1)
+	if err != nil {
+		log.Printf("❌ Error processing sprint answer: %v", err)
+		h.answerCallback(query.ID, "❌ Ошибка при обработке ответа")
+		return
+	}
+
+	if result.IsComplete {
+		h.answerCallback(query.ID, "⏰ Время вышло")
+		h.finishSprint(ctx, chatID, query.Message.MessageID, sprint)
+		return
+	}
+
+	h.answerCallback(query.ID, "")
+
+	feedback := fmt.Sprintf("❌ %s = %s", result.Original, result.Translation)
+	if !result.IsMatch {
+		feedback = fmt.Sprintf("❌ %s ≠ %s", result.Original, result.Translation)
+	}
+	if result.IsCorrect {
+		feedback = fmt.Sprintf("✅ +%d", result.Points)
+	}
+
+	keyboard := sprintKeyboard(sprint)
+	h.editMessage(chatID, query.Message.MessageID, sprintPairText(sprint, feedback), &keyboard)
+}
+
+// finishSprint показывает итоги на месте сообщения со спринтом. Под sprintsMu спринт
+// только снимается с учёта, поэтому повторный вызов для того же спринта ничего не делает.
+func (h *SimpleHandler) finishSprint(ctx context.Context, chatID int64, messageID int, sprint *domain.Sprint) {
+	h.sprintsMu.Lock()
+	active, exists := h.sprints[chatID]
+	if !exists || active.sprint != sprint {
+		h.sprintsMu.Unlock()
+		return
+	}
+	delete(h.sprints, chatID)
+	h.sprintsMu.Unlock()
+
+	result, err := h.sprintService.FinishSprint(ctx, sprint)
+	if err != nil {
+		log.Printf("❌ Error finishing sprint: %v", err)
+		h.editMessage(chatID, messageID, "❌ Не удалось сохранить результат спринта", nil)
+		return
+	}
+
+	response := fmt.Sprintf(`🏁 *Спринт завершён!*
+
+🏆 Очки: *%d*
+• Правильных ответов: %d/%d (%.0f%%)
+• Лучшая серия: %d
+• Рекорд: %d`,
+		result.Score,
+		result.Correct,
+		result.Answered,
+		result.Accuracy,
+		result.MaxCombo,
+		result.Best,
+	)
+	if result.IsNewBest {
+		response += "\n\n🎉 *Новый рекорд!*"
+	}
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(callbackButton("🏃 Ещё раз", callbackSprint, "new")),
+	)
+	h.editMessage(chatID, messageID, response, &keyboard)
+}
+
+func sprintPairText(sprint *domain.Sprint, feedback string) string {
+	text := fmt.Sprintf("🏃 *Спринт* ⏱ %.0f сек\nОчки: %d · множитель x%d",
+		sprint.TimeLeft().Seconds(), sprint.Score, sprint.Multiplier())
+	if feedback != "" {
+		text += "\n" + feedback
+	}
+
+	return text + fmt.Sprintf("\n\n*%s* = %s ?", sprint.Pair.Word.Original, sprint.Pair.Translation)
+}
+
+func sprintKeyboard(sprint *domain.Sprint) tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			callbackButton("✅ Верно", callbackSprint, sprint.Answered, 1),
+			callbackButton("❌ Неверно", callbackSprint, sprint.Answered, 0),
+		),
+	)
+}
@@ -0,0 +1,512 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"ivanSaichkin/language-bot/internal/constants"
+	"ivanSaichkin/language-bot/internal/domain"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+// Для чего выбирается слово из списка
+const (
+	wordPurposeEdit   = "e"
+	wordPurposeDelete = "d"
+)
+
+const (
+	editArgConfirm = "ok"
+	editArgCancel  = "no"
+)
+
+// Сколько слов показывать на одной странице выбора
+const wordPickerPageSize = 8
+
+var wordFieldLabels = map[string]string{
+	constants.WordFieldOriginal:     "слово",
+	constants.WordFieldTranslation:  "перевод",
+	constants.WordFieldExample:      "пример",
+	constants.WordFieldPartOfSpeech: "часть речи",
+	constants.WordFieldLanguage:     "язык",
+}
+
+var partOfSpeechLabels = map[string]string{
+	constants.PartOfSpeechNoun:      "существительное",
+	constants.PartOfSpeechVerb:      "глагол",
+	constants.PartOfSpeechAdjective: "прилагательное",
+	constants.PartOfSpeechAdverb:    "наречие",
+	constants.PartOfSpeechPhrase:    "фраза",
+}
+
+// pendingEdit - изменение поля, ждущее ввода значения или подтверждения
+type pendingEdit struct {
+	wordID int
+	field  string
+	value  string
+}
+
+// handleEditCommand: /edit [слово] - найти слово и изменить его поля
+func (h *SimpleHandler) handleEditCommand(ctx context.Context, chatID int64, args string) {
+	h.pickWord(ctx, chatID, strings.TrimSpace(args), wordPurposeEdit)
+}
+
+// handleDeleteCommand: /delete [слово] - найти слово и удалить его после подтверждения
+func (h *SimpleHandler) handleDeleteCommand(ctx context.Context, chatID int64, args string) {
+	h.pickWord(ctx, chatID, strings.TrimSpace(args), wordPurposeDelete)
+}
+
+// pickWord ищет слово по запросу; без запроса показывает постраничный список слов.
+// Если нашлось ровно одно слово, сразу открывает его.
+func (h *SimpleHandler) pickWord(ctx context.Context, chatID int64, query, purpose string) {
+	var words []*domain.Word
+	var err error
+	if query == "" {
+		words, err = h.wordService.GetUserWords(ctx, chatID)
+	} else {
+		words, err = h.wordService.SearchWords(ctx, chatID, query)
+	}
+	if err != nil {
+		log.Printf("❌ Error loading words: %v", err)
+		h.sendMessage(chatID, "❌ Не удалось загрузить слова")
+		return
+	}
+
+	if len(words) == 0 {
+		if query == "" {
+			h.sendMessage(chatID, "📝 В вашем словаре пока нет слов. Добавьте их через /add")
+			return
+		}
+		h.sendMessage(chatID, fmt.Sprintf("❌ Слово «%s» не найдено в вашем словаре", query))
+		return
+	}
+
+	if query != "" && len(words) == 1 {
+		text, keyboard := wordActionView(words[0], purpose)
+		h.sendMessageWithKeyboard(chatID, text, keyboard)
+		return
+	}
+
+	if query != "" && len(words) > wordPickerPageSize {
+		h.sendMessageWithKeyboard(chatID,
+			fmt.Sprintf("🔎 Найдено слов: %d, показаны первые %d. Уточните запрос, если нужного слова нет в списке.", len(words), wordPickerPageSize),
+			wordPickerKeyboard(words[:wordPickerPageSize], purpose, 0, 1))
+		return
+	}
+
+	text, keyboard := wordPickerPage(words, purpose, 0)
+	if query != "" {
+		text = fmt.Sprintf("🔎 Найдено слов: %d. Выберите нужное:", len(words))
+	}
+	h.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// handleWordPageCallback листает список слов для выбора
+func (h *SimpleHandler) handleWordPageCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+
+	page, err := data.Int(1)
+	if err != nil {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return
+	}
+
+	words, err := h.wordService.GetUserWords(ctx, chatID)
+	if err != nil {
+		log.Printf("❌ Error loading words: %v", err)
+		h.answerCallback(query.ID, "❌ Не удалось загрузить слова")
+		return
+	}
+
+	h.answerCallback(query.ID, "")
+	text, keyboard := wordPickerPage(words, data.Arg(0), page)
+	h.editMessage(chatID, query.Message.MessageID, text, &keyboard)
+}
+
+// handleWordPickCallback открывает выбранное слово: карточку правки или подтверждение удаления
+func (h *SimpleHandler) handleWordPickCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+
+	word := h.callbackWord(ctx, query, data, 1)
+	if word == nil {
+		return
+	}
+
+	h.answerCallback(query.ID, "")
+	text, keyboard := wordActionView(word, data.Arg(0))
+	h.editMessage(chatID, query.Message.MessageID, text, &keyboard)
+}
+
+// handleEditFieldCallback: для текстовых полей просит ввести новое значение,
+// для части речи и языка показывает варианты кнопками
+func (h *SimpleHandler) handleEditFieldCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+
+	word := h.callbackWord(ctx, query, data, 0)
+	if word == nil {
+		return
+	}
+
+	field := data.Arg(1)
+	label, ok := wordFieldLabels[field]
+	if !ok {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return
+	}
+
+	h.answerCallback(query.ID, "")
+
+	switch field {
+	case constants.WordFieldPartOfSpeech, constants.WordFieldLanguage:
+		text := fmt.Sprintf("✏️ *%s*\n\nВыберите %s:", word.Original, label)
+		keyboard := editOptionsKeyboard(word, field)
+		h.editMessage(chatID, query.Message.MessageID, text, &keyboard)
+		return
+	}
+
+	if err := h.userService.SetUserState(ctx, chatID, string(constants.StateAwaitingEdit)); err != nil {
+		h.sendMessage(chatID, "❌ Ошибка при изменении состояния")
+		return
+	}
+	h.stateMu.Lock()
+	h.edits[chatID] = &pendingEdit{wordID: word.ID, field: field}
+	h.stateMu.Unlock()
+
+	hint := ""
+	switch field {
+	case constants.WordFieldTranslation:
+		hint = "\nНесколько переводов - через запятую."
+	case constants.WordFieldExample:
+		hint = "\nЧтобы удалить пример, отправьте «-»."
+	}
+
+	h.removeKeyboard(chatID, query.Message.MessageID)
+	h.sendMessage(chatID, fmt.Sprintf("✏️ *%s*\n\nСейчас %s: %s\nОтправьте новое значение.%s",
+		word.Original, label, wordFieldValue(word, field), hint))
+}
+
+// handleEditValueCallback - значение поля выбрано кнопкой
+func (h *SimpleHandler) handleEditValueCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+
+	word := h.callbackWord(ctx, query, data, 0)
+	if word == nil {
+		return
+	}
+
+	edit := &pendingEdit{wordID: word.ID, field: data.Arg(1), value: data.Arg(2)}
+	text, ok := editConfirmationText(word, edit)
+	if !ok {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return
+	}
+
+	h.stateMu.Lock()
+	h.edits[chatID] = edit
+	h.stateMu.Unlock()
+	h.answerCallback(query.ID, "")
+	keyboard := editConfirmKeyboard()
+	h.editMessage(chatID, query.Message.MessageID, text, &keyboard)
+}
+
+// handleEditInput принимает новое значение текстового поля и просит подтвердить изменение
+func (h *SimpleHandler) handleEditInput(ctx context.Context, chatID int64, text string) {
+	h.stateMu.Lock()
+	edit, exists := h.edits[chatID]
+	h.stateMu.Unlock()
+	if !exists {
+		h.resetEditState(ctx, chatID)
+		h.sendMessage(chatID, "❌ Изменение уже отменено. Начните заново: /edit")
+		return
+	}
+
+	word, err := h.wordService.GetUserWord(ctx, chatID, edit.wordID)
+	if err != nil {
+		if !isWordUnavailable(err) {
+			log.Printf("❌ Error loading word: %v", err)
+		}
+		h.stateMu.Lock()
+		delete(h.edits, chatID)
+		h.stateMu.Unlock()
+		h.resetEditState(ctx, chatID)
+		h.sendMessage(chatID, "❌ Слово не найдено в вашем словаре")
+		return
+	}
+
+	edit.value = strings.TrimSpace(text)
+	response, ok := editConfirmationText(word, edit)
+	if !ok {
+		h.sendMessage(chatID, fmt.Sprintf("❌ Некорректное значение поля «%s». Отправьте его ещё раз.", wordFieldLabels[edit.field]))
+		return
+	}
+
+	h.resetEditState(ctx, chatID)
+	h.sendMessageWithKeyboard(chatID, response, editConfirmKeyboard())
+}
+
+// handleEditConfirmCallback сохраняет или отменяет изменение поля
+func (h *SimpleHandler) handleEditConfirmCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+	messageID := query.Message.MessageID
+
+	h.stateMu.Lock()
+	edit, exists := h.edits[chatID]
+	h.stateMu.Unlock()
+	if !exists {
+		h.answerCallback(query.ID, "Изменение уже сохранено или отменено")
+		h.removeKeyboard(chatID, messageID)
+		return
+	}
+	h.stateMu.Lock()
+	delete(h.edits, chatID)
+	h.stateMu.Unlock()
+
+	if data.Arg(0) != editArgConfirm {
+		h.answerCallback(query.ID, "")
+		h.editMessage(chatID, messageID, "❌ Изменение отменено", nil)
+		return
+	}
+
+	word, err := h.wordService.EditWord(ctx, chatID, edit.wordID, edit.field, edit.value)
+	if isWordUnavailable(err) {
+		h.answerCallback(query.ID, "❌ Слово не найдено")
+		h.editMessage(chatID, messageID, "❌ Слово не найдено в вашем словаре", nil)
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Error editing word: %v", err)
+		h.answerCallback(query.ID, "❌ Не удалось сохранить")
+		h.editMessage(chatID, messageID, "❌ Не удалось сохранить изменение", nil)
+		return
+	}
+
+	h.answerCallback(query.ID, "✅ Сохранено")
+	text, keyboard := wordActionView(word, wordPurposeEdit)
+	h.editMessage(chatID, messageID, "✅ Изменение сохранено\n\n"+text, &keyboard)
+}
+
+// handleDeleteWordCallback удаляет слово после подтверждения
+func (h *SimpleHandler) handleDeleteWordCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+	messageID := query.Message.MessageID
+
+	wordID, err := data.Int(0)
+	if err != nil {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return
+	}
+
+	if data.Arg(1) != editArgConfirm {
+		h.answerCallback(query.ID, "")
+		h.editMessage(chatID, messageID, "❌ Удаление отменено", nil)
+		return
+	}
+
+	word, err := h.wordService.DeleteUserWord(ctx, chatID, wordID)
+	if isWordUnavailable(err) {
+		h.answerCallback(query.ID, "❌ Слово не найдено")
+		h.editMessage(chatID, messageID, "❌ Слово не найдено в вашем словаре", nil)
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Error deleting word: %v", err)
+		h.answerCallback(query.ID, "❌ Не удалось удалить")
+		return
+	}
+
+	h.answerCallback(query.ID, "🗑 Удалено")
+	h.editMessage(chatID, messageID, fmt.Sprintf("🗑 Слово *%s* удалено из словаря", word.Original), nil)
+}
+
+// callbackWord читает ID слова из кнопки и проверяет, что слово принадлежит пользователю
+func (h *SimpleHandler) callbackWord(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData, index int) *domain.Word {
+	wordID, err := data.Int(index)
+	if err != nil {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return nil
+	}
+
+	word, err := h.wordService.GetUserWord(ctx, query.Message.Chat.ID, wordID)
+	if isWordUnavailable(err) {
+		h.answerCallback(query.ID, "❌ Слово не найдено")
+		h.removeKeyboard(query.Message.Chat.ID, query.Message.MessageID)
+		return nil
+	}
+	if err != nil {
+		log.Printf("❌ Error loading word: %v", err)
+		h.answerCallback(query.ID, "❌ Не удалось загрузить слово")
+		return nil
+	}
+
+	return word
+}
+
+// isWordUnavailable: слова нет или оно чужое - пользователю это выглядит одинаково
+func isWordUnavailable(err error) bool {
+	return errors.Is(err, domain.ErrWordNotFound) || errors.Is(err, domain.ErrWordForbidden)
+}
+
+func (h *SimpleHandler) resetEditState(ctx context.Context, chatID int64) {
+	if err := h.userService.SetUserState(ctx, chatID, ""); err != nil {
+		log.Printf("⚠️ Failed to reset user state: %v", err)
+	}
+}
+
+// editConfirmationText показывает «было → станет»; false - значение не подходит для поля
+func editConfirmationText(word *domain.Word, edit *pendingEdit) (string, bool) {
+	updated := *word
+	if err := updated.SetField(edit.field, edit.value); err != nil {
+		return "", false
+	}
+
+	return fmt.Sprintf("✏️ *%s*: изменить %s?\n\nБыло: %s\nСтанет: %s",
+		word.Original, wordFieldLabels[edit.field], wordFieldValue(word, edit.field), wordFieldValue(&updated, edit.field)), true
+}
+
+func wordFieldValue(word *domain.Word, field string) string {
+	var value string
+	switch field {
+	case constants.WordFieldOriginal:
+		value = word.Original
+	case constants.WordFieldTranslation:
+		value = word.DisplayTranslation()
+	case constants.WordFieldExample:
+		value = word.Example
+	case constants.WordFieldPartOfSpeech:
+		value = partOfSpeechLabels[word.PartOfSpeech]
+	case constants.WordFieldLanguage:
+		value = domain.LanguageLabel(word.Language)
+	}
+
+	if value == "" {
+		return "—"
+	}
+
+	return value
+}
+
+// wordActionView - карточка слова с кнопками правки полей или подтверждение удаления
+func wordActionView(word *domain.Word, purpose string) (string, tgbotapi.InlineKeyboardMarkup) {
+	if purpose == wordPurposeDelete {
+		text := fmt.Sprintf("🗑 Удалить слово *%s* - %s?\n\nПрогресс повторений этого слова будет потерян.", word.Original, word.DisplayTranslation())
+		return text, tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+			callbackButton("🗑 Удалить", callbackDeleteWord, word.ID, editArgConfirm),
+			callbackButton("Отмена", callbackDeleteWord, word.ID, editArgCancel),
+		))
+	}
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("✏️ *%s*\n\n", word.Original))
+	for _, field := range []string{
+		constants.WordFieldOriginal,
+		constants.WordFieldTranslation,
+		constants.WordFieldExample,
+		constants.WordFieldPartOfSpeech,
+		constants.WordFieldLanguage,
+	} {
+		text.WriteString(fmt.Sprintf("• %s: %s\n", wordFieldLabels[field], wordFieldValue(word, field)))
+	}
+	text.WriteString("\nЧто изменить?")
+
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			callbackButton("Слово", callbackEditField, word.ID, constants.WordFieldOriginal),
+			callbackButton("Перевод", callbackEditField, word.ID, constants.WordFieldTranslation),
+			callbackButton("Пример", callbackEditField, word.ID, constants.WordFieldExample),
+		),
+		tgbotapi.NewInlineKeyboardRow(
+			callbackButton("Часть речи", callbackEditField, word.ID, constants.WordFieldPartOfSpeech),
+			callbackButton("Язык", callbackEditField, word.ID, constants.WordFieldLanguage),
+		),
+		tgbotapi.NewInlineKeyboardRow(callbackButton("🗑 Удалить слово", callbackWordPick, wordPurposeDelete, word.ID)),
+	)
+
+	return text.String(), keyboard
+}
+
+// wordPickerPage - страница списка слов для выбора
+func wordPickerPage(words []*domain.Word, purpose string, page int) (string, tgbotapi.InlineKeyboardMarkup) {
+	pages := (len(words) + wordPickerPageSize - 1) / wordPickerPageSize
+	page = max(0, min(page, pages-1))
+	start := page * wordPickerPageSize
+
+	title := "✏️ Выберите слово для изменения"
+	if purpose == wordPurposeDelete {
+		title = "🗑 Выберите слово для удаления"
+	}
+	text := fmt.Sprintf("%s (страница %d из %d).\nМожно искать по слову или переводу: /edit слово, /delete слово", title, page+1, pages)
+
+	return text, wordPickerKeyboard(words[start:min(start+wordPickerPageSize, len(words))], purpose, page, pages)
+}
+
+func wordPickerKeyboard(words []*domain.Word, purpose string, page, pages int) tgbotapi.InlineKeyboardMarkup {
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for _, word := range words {
+		label := fmt.Sprintf("%s - %s", word.Original, word.DisplayTranslation())
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(callbackButton(label, callbackWordPick, purpose, word.ID)))
+	}
+
+	if pages > 1 {
+		var navigation []tgbotapi.InlineKeyboardButton
+		if page > 0 {
+			navigation = append(navigation, callbackButton("⬅️", callbackWordPage, purpose, page-1))
+		}
+		navigation = append(navigation, callbackButton(fmt.Sprintf("%d/%d", page+1, pages), callbackNoop))
+		if page < pages-1 {
+			navigation = append(navigation, callbackButton("➡️", callbackWordPage, purpose, page+1))
+		}
+		rows = append(rows, navigation)
+	}
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+// editOptionsKeyboard - варианты части речи или языка, текущий отмечен галочкой
+func editOptionsKeyboard(word *domain.Word, field string) tgbotapi.InlineKeyboardMarkup {
+	type option struct{ value, label string }
+
+	var options []option
+	current := word.Language
+	if field == constants.WordFieldPartOfSpeech {
+		current = word.PartOfSpeech
+		for _, pos := range domain.PartsOfSpeech {
+			options = append(options, option{pos, partOfSpeechLabels[pos]})
+		}
+	} else {
+		for _, language := range domain.SupportedLanguages {
+			options = append(options, option{language.Code, language.Label()})
+		}
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	var row []tgbotapi.InlineKeyboardButton
+	for _, option := range options {
+		label := option.label
+		if option.value == current {
+			label = "✓ " + label
+		}
+
+		row = append(row, callbackButton(label, callbackEditValue, word.ID, field, option.value))
+		if len(row) == 2 {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	rows = append(rows, tgbotapi.NewInlineKeyboardRow(callbackButton("⬅️ Назад", callbackWordPick, wordPurposeEdit, word.ID)))
+
+	return tgbotapi.NewInlineKeyboardMarkup(rows...)
+}
+
+func editConfirmKeyboard() tgbotapi.InlineKeyboardMarkup {
+	return tgbotapi.NewInlineKeyboardMarkup(tgbotapi.NewInlineKeyboardRow(
+		callbackButton("✅ Сохранить", callbackEditConfirm, editArgConfirm),
+		callbackButton("Отмена", callbackEditConfirm, editArgCancel),
+	))
+}
@@ -0,0 +1,444 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"ivanSaichkin/language-bot/internal/constants"
+	"ivanSaichkin/language-bot/internal/domain"
+	"ivanSaichkin/language-bot/internal/service"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+)
+
+const (
+	wordListPageSize     = 8
+	wordHistoryLimit     = 15
+	wordListButtonsInRow = 4
+)
+
+var wordSortLabels = map[string]string{
+	constants.WordSortAlphabet: "А-Я",
+	constants.WordSortNewest:   "Новые",
+	constants.WordSortDue:      "По сроку",
+	constants.WordSortWeakest:  "Слабые",
+}
+
+var wordStatusLabels = map[string]string{
+	"":                           "Все",
+	constants.WordStatusDue:      "Повторить",
+	constants.WordStatusLearning: "Изучаю",
+	constants.WordStatusLearned:  "Выучены",
+}
+
+var reviewModeLabels = map[string]string{
+	constants.ReviewModeTyping: "ввод",
+	constants.ReviewModeChoice: "выбор",
+	constants.ReviewModeCloze:  "пропуски",
+	constants.GameModeSprint:   "спринт",
+	constants.GameModeMatch:    "пары",
+}
+
+var cardTypeLabels = map[string]string{
+	constants.CardTypeForward: "слово → перевод",
+	constants.CardTypeReverse: "перевод → слово",
+	constants.CardTypeCloze:   "пример с пропуском",
+}
+
+// wordBrowser - состояние списка /words. Оно целиком передаётся в кнопках,
+// поэтому список можно листать и после перезапуска бота.
+type wordBrowser struct {
+	sort         string
+	status       string
+	language     string // пустая строка - все языки
+	partOfSpeech string // пустая строка - любая часть речи
+	deckID       int
+	page         int
+}
+
+func (b wordBrowser) args() []any {
+	return []any{b.sort, b.status, b.language, b.partOfSpeech, b.deckID, b.page}
+}
+
+// parseWordBrowser читает состояние списка из аргументов кнопки, начиная с offset
+func parseWordBrowser(data callbackData, offset int) (wordBrowser, error) {
+	deckID, err := data.Int(offset + 4)
+	if err != nil {
+		return wordBrowser{}, err
+	}
+
+	page, err := data.Int(offset + 5)
+	if err != nil {
+		return wordBrowser{}, err
+	}
+
+	return wordBrowser{
+		sort:         data.Arg(offset),
+		status:       data.Arg(offset + 1),
+		language:     data.Arg(offset + 2),
+		partOfSpeech: data.Arg(offset + 3),
+		deckID:       deckID,
+		page:         page,
+	}, nil
+}
+
+// handleWordsCommand: /words [колода] - постраничный список слов изучаемого языка или колоды
+func (h *SimpleHandler) handleWordsCommand(ctx context.Context, chatID int64, args string) {
+	browser := wordBrowser{sort: constants.WordSortDue}
+	if deckName := strings.TrimSpace(args); deckName != "" {
+		deck := h.findDeckOrReport(ctx, chatID, deckName)
+		if deck == nil {
+			return
+		}
+		browser.deckID = deck.ID
+	}
+	browser.language = h.wordFilter(ctx, chatID, browser.deckID).Language
+
+	text, keyboard, err := h.wordListView(ctx, chatID, browser)
+	if err != nil {
+		log.Printf("❌ Error loading words: %v", err)
+		h.sendMessage(chatID, "❌ Не удалось загрузить слова")
+		return
+	}
+
+	h.sendMessageWithKeyboard(chatID, text, keyboard)
+}
+
+// handleWordListCallback перерисовывает список с новой страницей, сортировкой или фильтром
+func (h *SimpleHandler) handleWordListCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+
+	browser, err := parseWordBrowser(data, 0)
+	if err != nil {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return
+	}
+
+	text, keyboard, err := h.wordListView(ctx, chatID, browser)
+	if err != nil {
+		log.Printf("❌ Error loading words: %v", err)
+		h.answerCallback(query.ID, "❌ Не удалось загрузить слова")
+		return
+	}
+
+	h.answerCallback(query.ID, "")
+	h.editMessage(chatID, query.Message.MessageID, text, &keyboard)
+}
+
+// handleWordCardCallback открывает карточку слова с расписанием и историей ответов
+func (h *SimpleHandler) handleWordCardCallback(ctx context.Context, query *tgbotapi.CallbackQuery, data callbackData) {
+	chatID := query.Message.Chat.ID
+
+	wordID, err := data.Int(0)
+	if err != nil {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return
+	}
+
+	browser, err := parseWordBrowser(data, 1)
+	if err != nil {
+		h.answerCallback(query.ID, "❌ Неверные данные кнопки")
+		return
+	}
+
+	details, err := h.wordService.GetWordDetails(ctx, chatID, wordID, wordHistoryLimit)
+	if isWordUnavailable(err) {
+		h.answerCallback(query.ID, "❌ Слово не найдено")
+		return
+	}
+	if err != nil {
+		log.Printf("❌ Error loading word details: %v", err)
+		h.answerCallback(query.ID, "❌ Не удалось загрузить слово")
+		return
+	}
+
+	user, err := h.userService.GetUser(ctx, chatID)
+	if err != nil {
+		log.Printf("❌ Error loading user: %v", err)
+		h.answerCallback(query.ID, "❌ Не удалось загрузить слово")
+		return
+	}
+
+	deckName := ""
+	if details.Word.DeckID != 0 {
+		if deck, err := h.deckService.GetDeck(ctx, chatID, details.Word.DeckID); err == nil && deck != nil {
+			deckName = deck.Name
+		}
+	}
+
+	word := details.Word
+	keyboard := tgbotapi.NewInlineKeyboardMarkup(
+		tgbotapi.NewInlineKeyboardRow(
+			callbackButton("✏️ Изменить", callbackWordPick, wordPurposeEdit, word.ID),
+			callbackButton("🗑 Удалить", callbackWordPick, wordPurposeDelete, word.ID),
+		),
+		tgbotapi.NewInlineKeyboardRow(callbackButton("⬅️ К списку", callbackWordList, browser.args()...)),
+	)
+
+	h.answerCallback(query.ID, "")
+	h.editMessage(chatID, query.Message.MessageID, wordDetailsText(details, deckName, user.Location()), &keyboard)
+}
+
+// wordListView формирует страницу списка слов с кнопками листания, сортировки и фильтров
+func (h *SimpleHandler) wordListView(ctx context.Context, chatID int64, browser wordBrowser) (string, tgbotapi.InlineKeyboardMarkup, error) {
+	filter := domain.WordFilter{DeckID: browser.deckID, Language: browser.language}
+	page, err := h.wordService.ListWords(ctx, chatID, service.WordListQuery{
+		Filter:       filter,
+		Status:       browser.status,
+		PartOfSpeech: browser.partOfSpeech,
+		Sort:         browser.sort,
+		Page:         browser.page,
+		PageSize:     wordListPageSize,
+	})
+	if err != nil {
+		return "", tgbotapi.InlineKeyboardMarkup{}, err
+	}
+	browser.page = page.Page
+
+	var deck *domain.Deck
+	if browser.deckID != 0 {
+		if deck, err = h.deckService.GetDeck(ctx, chatID, browser.deckID); err != nil {
+			return "", tgbotapi.InlineKeyboardMarkup{}, err
+		}
+	}
+
+	var text strings.Builder
+	switch {
+	case deck != nil:
+		text.WriteString(fmt.Sprintf("📂 *Колода «%s»*\n", deck.Name))
+	case browser.language != "":
+		text.WriteString(fmt.Sprintf("📖 *Ваши слова* · %s\n", domain.LanguageLabel(browser.language)))
+	default:
+		text.WriteString("📖 *Ваши слова* · все языки\n")
+	}
+
+	filtered := browser.status != "" || browser.partOfSpeech != ""
+	if page.Total == 0 && !filtered {
+		response := "📝 У вас пока нет слов для изучения. Используйте /add чтобы добавить первые слова!"
+		if deck == nil && browser.language != "" && len(page.Languages) > 0 {
+			response = fmt.Sprintf("📝 У вас пока нет слов на языке: %s\nДобавьте их через /add или выберите другой язык: /language",
+				domain.LanguageLabel(browser.language))
+		}
+
+		var rows [][]tgbotapi.InlineKeyboardButton
+		if deck == nil && browser.language != "" && len(page.Languages) > 0 {
+			all := browser
+			all.language, all.page = "", 0
+			rows = append(rows, tgbotapi.NewInlineKeyboardRow(callbackButton("🌍 Все языки", callbackWordList, all.args()...)))
+		}
+		rows = append(rows, tgbotapi.NewInlineKeyboardRow(callbackButton("➕ Добавить слово", callbackAddWord)))
+
+		return response, tgbotapi.NewInlineKeyboardMarkup(rows...), nil
+	}
+
+	text.WriteString(fmt.Sprintf("Сортировка: %s · показаны: %s", strings.ToLower(wordSortLabels[browser.sort]), strings.ToLower(wordStatusLabels[browser.status])))
+	if browser.partOfSpeech != "" {
+		text.WriteString(fmt.Sprintf(" · %s", partOfSpeechLabels[browser.partOfSpeech]))
+	}
+	text.WriteString("\n\n")
+
+	if page.Total == 0 {
+		text.WriteString("🔎 Под выбранный фильтр не подходит ни одно слово.\n")
+	}
+
+	var numbers []tgbotapi.InlineKeyboardButton
+	for i, word := range page.Words {
+		number := page.Page*wordListPageSize + i + 1
+		text.WriteString(fmt.Sprintf("%d. %s *%s* - %s · %.0f%%\n",
+			number, wordStatusIcon(word), word.Original, word.DisplayTranslation(), word.GetProgress()))
+
+		numbers = append(numbers, callbackButton(fmt.Sprint(number), callbackWordCard, append([]any{word.ID}, browser.args()...)...))
+	}
+
+	if page.Total > 0 {
+		text.WriteString(fmt.Sprintf("\nСлов: %d · страница %d из %d\n🟢 изучается · 🟡 пора повторить · 🔵 выучено\nНажмите на номер, чтобы открыть карточку слова.",
+			page.Total, page.Page+1, page.Pages))
+	}
+	if page.Due > 0 {
+		text.WriteString(fmt.Sprintf("\n\n⏰ *Слов для повторения: %d* /review", page.Due))
+	}
+
+	var rows [][]tgbotapi.InlineKeyboardButton
+	for start := 0; start < len(numbers); start += wordListButtonsInRow {
+		rows = append(rows, numbers[start:min(start+wordListButtonsInRow, len(numbers))])
+	}
+
+	if page.Pages > 1 {
+		var navigation []tgbotapi.InlineKeyboardButton
+		if page.Page > 0 {
+			navigation = append(navigation, wordListButton("⬅️", browser, func(b *wordBrowser) { b.page-- }))
+		}
+		navigation = append(navigation, callbackButton(fmt.Sprintf("%d/%d", page.Page+1, page.Pages), callbackNoop))
+		if page.Page < page.Pages-1 {
+			navigation = append(navigation, wordListButton("➡️", browser, func(b *wordBrowser) { b.page++ }))
+		}
+		rows = append(rows, navigation)
+	}
+
+	var sorts []tgbotapi.InlineKeyboardButton
+	for _, order := range []string{constants.WordSortAlphabet, constants.WordSortNewest, constants.WordSortDue, constants.WordSortWeakest} {
+		sorts = append(sorts, wordListButton(checkedLabel(wordSortLabels[order], order == browser.sort), browser, func(b *wordBrowser) {
+			b.sort, b.page = order, 0
+		}))
+	}
+	rows = append(rows, sorts)
+
+	var statuses []tgbotapi.InlineKeyboardButton
+	for _, status := range []string{"", constants.WordStatusDue, constants.WordStatusLearning, constants.WordStatusLearned} {
+		statuses = append(statuses, wordListButton(checkedLabel(wordStatusLabels[status], status == browser.status), browser, func(b *wordBrowser) {
+			b.status, b.page = status, 0
+		}))
+	}
+	rows = append(rows, statuses)
+
+	// Язык и часть речи переключаются по кругу, чтобы не занимать много строк
+	var cycles []tgbotapi.InlineKeyboardButton
+	if deck == nil && len(page.Languages) > 0 {
+		label := "🌍 Все языки"
+		if browser.language != "" {
+			label = "🌍 " + domain.LanguageLabel(browser.language)
+		}
+		cycles = append(cycles, wordListButton(label, browser, func(b *wordBrowser) {
+			b.language, b.page = nextOption(append([]string{""}, page.Languages...), b.language), 0
+		}))
+	}
+
+	posLabel := "🏷 Любая часть речи"
+	if browser.partOfSpeech != "" {
+		posLabel = "🏷 " + partOfSpeechLabels[browser.partOfSpeech]
+	}
+	cycles = append(cycles, wordListButton(posLabel, browser, func(b *wordBrowser) {
+		b.partOfSpeech, b.page = nextOption(append([]string{""}, domain.PartsOfSpeech...), b.partOfSpeech), 0
+	}))
+	rows = append(rows, cycles)
+
+	rows = append(rows,
+		tgbotapi.NewInlineKeyboardRow(
+			callbackButton("🔁 Повторить", callbackReview, constants.ReviewModeTyping, browser.deckID),
+			callbackButton("🔘 С вариантами", callbackReview, constants.ReviewModeChoice, browser.deckID),
+		),
+		tgbotapi.NewInlineKeyboardRow(callbackButton("➕ Добавить слово", callbackAddWord)),
+	)
+
+	return text.String(), tgbotapi.NewInlineKeyboardMarkup(rows...), nil
+}
+
+// wordListButton - кнопка списка, которая открывает его в изменённом состоянии
+func wordListButton(text string, browser wordBrowser, change func(b *wordBrowser)) tgbotapi.InlineKeyboardButton {
+	change(&browser)
+	return callbackButton(text, callbackWordList, browser.args()...)
+}
+
+func wordDetailsText(details *service.WordDetails, deckName string, location *time.Location) string {
+	word := details.Word
+
+	var text strings.Builder
+	text.WriteString(fmt.Sprintf("🔎 *%s* - %s\n", word.Original, word.DisplayTranslation()))
+	if word.Example != "" {
+		text.WriteString(fmt.Sprintf("💬 %s\n", word.Example))
+	}
+	text.WriteString(fmt.Sprintf("\n🌍 %s", domain.LanguageLabel(word.Language)))
+	if label := partOfSpeechLabels[word.PartOfSpeech]; label != "" {
+		text.WriteString(" · " + label)
+	}
+	if deckName != "" {
+		text.WriteString(fmt.Sprintf(" · 📂 %s", deckName))
+	}
+
+	text.WriteString("\n\n📅 *Расписание*\n")
+	text.WriteString(fmt.Sprintf("%s Следующее повторение: %s\n", wordStatusIcon(word), formatDateTime(word.NextReview, location)))
+	if word.ReviewCount > 0 {
+		text.WriteString(fmt.Sprintf("Интервал: %s\n", formatIntervalDays(word.IntervalDays)))
+	}
+	if word.Stability > 0 {
+		text.WriteString(fmt.Sprintf("FSRS: стабильность %.1f дн., сложность %.1f\n", word.Stability, word.FSRSDifficulty))
+	} else {
+		text.WriteString(fmt.Sprintf("Лёгкость: %.2f\n", word.Difficulty))
+	}
+	text.WriteString(fmt.Sprintf("Повторений: %d · верно подряд: %d · прогресс %.0f%%\n", word.ReviewCount, word.CorrectAnswers, word.GetProgress()))
+	if !word.LastReviewedAt.IsZero() {
+		text.WriteString(fmt.Sprintf("Последнее повторение: %s\n", formatDateTime(word.LastReviewedAt, location)))
+	}
+	text.WriteString(fmt.Sprintf("Добавлено: %s\n", word.CreatedAt.In(location).Format("02.01.2006")))
+
+	if len(details.Cards) > 0 {
+		text.WriteString("\n🃏 *Карточки*\n")
+		for _, card := range details.Cards {
+			text.WriteString(fmt.Sprintf("• %s: %s, повторений %d\n",
+				cardTypeLabels[card.CardType], formatDateTime(card.NextReview, location), card.ReviewCount))
+		}
+	}
+
+	text.WriteString("\n📜 *История ответов*\n")
+	if len(details.History) == 0 {
+		text.WriteString("Слово ещё не повторялось")
+		return text.String()
+	}
+
+	for _, entry := range details.History {
+		result := "❌"
+		if entry.IsCorrect {
+			result = "✅"
+		}
+
+		line := fmt.Sprintf("%s %s %s", entry.ReviewedAt.In(location).Format("02.01 15:04"), result, reviewModeLabels[entry.Mode])
+		if entry.CardType != "" && entry.CardType != constants.CardTypeForward {
+			line += fmt.Sprintf(" (%s)", cardTypeLabels[entry.CardType])
+		}
+
+		// Игры не меняют расписание, для них интервалы не показываются
+		if entry.Mode != constants.GameModeSprint && entry.Mode != constants.GameModeMatch {
+			line += fmt.Sprintf(" · %s · %s → %s", gradeLabel(domain.GradeFromQuality(entry.Quality)),
+				formatIntervalDays(entry.PreviousInterval), formatIntervalDays(entry.NextInterval))
+		}
+		text.WriteString(line + "\n")
+	}
+
+	return text.String()
+}
+
+func wordStatusIcon(word *domain.Word) string {
+	switch {
+	case word.IsLearned():
+		return "🔵"
+	case word.IsDueForReview():
+		return "🟡"
+	default:
+		return "🟢"
+	}
+}
+
+func formatDateTime(t time.Time, location *time.Location) string {
+	return t.In(location).Format("02.01.2006 15:04")
+}
+
+// formatIntervalDays показывает короткие интервалы в часах, длинные - в днях
+func formatIntervalDays(days float64) string {
+	if days < 1 {
+		return fmt.Sprintf("%.0f ч", days*24)
+	}
+
+	return fmt.Sprintf("%.0f дн.", days)
+}
+
+func checkedLabel(label string, checked bool) string {
+	if checked {
+		return "✓ " + label
+	}
+
+	return label
+}
+
+// nextOption возвращает значение, следующее за current, по кругу
+func nextOption(options []string, current string) string {
+	for i, option := range options {
+		if option == current {
+			return options[(i+1)%len(options)]
+		}
+	}
+
+	return options[0]
+}
@@ -8,13 +8,16 @@ const (
 	StateInReview         UserState = "in_review"
 	StateInTest           UserState = "in_test"
 	StateAwaitingLanguage UserState = "awaiting_language"
+	StateAwaitingEdit     UserState = "awaiting_edit"
 )
 
+// Коды изучаемых языков (ISO 639-1). Список для выбора - domain.SupportedLanguages
 const (
 	LanguageEnglish = "en"
 	LanguageGerman  = "de"
-	LanguageFranch  = "fr"
+	LanguageFrench  = "fr"
 	LanguageSpanish = "es"
+	LanguageItalian = "it"
 	LanguageRussian = "ru"
 )
 
@@ -25,3 +28,66 @@ const (
 	PartOfSpeechAdverb    = "adverb"
 	PartOfSpeechPhrase    = "phrase"
 )
+
+// Поля слова, которые можно изменить через /edit
+const (
+	WordFieldOriginal     = "original"
+	WordFieldTranslation  = "translation"
+	WordFieldExample      = "example"
+	WordFieldPartOfSpeech = "pos"
+	WordFieldLanguage     = "language"
+)
+
+// Сортировка списка слов /words. Коды короткие, потому что передаются в кнопках
+const (
+	WordSortAlphabet = "az"
+	WordSortNewest   = "new"
+	WordSortDue      = "due"
+	WordSortWeakest  = "weak"
+)
+
+// Фильтры списка слов по состоянию изучения; пустая строка - все слова
+const (
+	WordStatusDue      = "due"
+	WordStatusLearning = "learning"
+	WordStatusLearned  = "learned"
+)
+
+const (
+	SchedulerSM2  = "sm2"
+	SchedulerFSRS = "fsrs"
+)
+
+// Типы карточек: прямая (оригинал → перевод), обратная (перевод → оригинал)
+// и пример с пропуском на месте слова
+const (
+	CardTypeForward = "forward"
+	CardTypeReverse = "reverse"
+	CardTypeCloze   = "cloze"
+)
+
+// Режимы повторения: ввод ответа или выбор из вариантов
+const (
+	ReviewModeTyping = "typing"
+	ReviewModeChoice = "choice"
+	ReviewModeCloze  = "cloze"
+)
+
+// Игровые режимы пишутся в журнал ответов, но не меняют расписание
+// и не засчитываются в дневную цель
+const (
+	GameModeSprint = "sprint"
+	GameModeMatch  = "match"
+)
+
+// Какие карточки создавать для новых слов
+const (
+	CardDirectionsForward = "forward"
+	CardDirectionsBoth    = "both"
+)
+
+const (
+	StrictnessStrict  = "strict"
+	StrictnessNormal  = "normal"
+	StrictnessLenient = "lenient"
+)
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"ivanSaichkin/language-bot/internal/constants"
+	"time"
+)
+
+// Card - дополнительная карточка слова (например, обратная: перевод → оригинал).
+// У каждой карточки своё состояние повторения, независимое от слова.
+type Card struct {
+	ID             int       `json:"id"`
+	WordID         int       `json:"word_id"`
+	UserID         int64     `json:"user_id"`
+	CardType       string    `json:"card_type"`
+	Difficulty     float64   `json:"difficulty"`
+	NextReview     time.Time `json:"next_review"`
+	ReviewCount    int       `json:"review_count"`
+	CorrectAnswers int       `json:"correct_answers"`
+	IntervalDays   float64   `json:"interval_days"`
+	LastReviewedAt time.Time `json:"last_reviewed_at"`
+	Stability      float64   `json:"stability"`
+	FSRSDifficulty float64   `json:"fsrs_difficulty"`
+	Retrievability float64   `json:"retrievability"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+func NewReverseCard(word *Word) *Card {
+	return newCard(word, constants.CardTypeReverse)
+}
+
+// NewClozeCard создаёт карточку с пропуском; nil, если слово не найдено в примере
+func NewClozeCard(word *Word) *Card {
+	if _, _, ok := word.Cloze(); !ok {
+		return nil
+	}
+
+	return newCard(word, constants.CardTypeCloze)
+}
+
+func newCard(word *Word, cardType string) *Card {
+	now := time.Now()
+
+	return &Card{
+		WordID:     word.ID,
+		UserID:     word.UserID,
+		CardType:   cardType,
+		Difficulty: 2.5,
+		NextReview: now,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// ResetProgress сбрасывает состояние повторения карточки
+func (c *Card) ResetProgress() {
+	now := time.Now()
+
+	c.Difficulty = 2.5
+	c.NextReview = now
+	c.ReviewCount = 0
+	c.CorrectAnswers = 0
+	c.IntervalDays = 0
+	c.LastReviewedAt = time.Time{}
+	c.Stability = 0
+	c.FSRSDifficulty = 0
+	c.Retrievability = 0
+	c.UpdatedAt = now
+}
+
+// ForReview возвращает копию слова с состоянием этой карточки,
+// чтобы планировщики и сессии работали с ней как с обычным словом
+func (c *Card) ForReview(word *Word) *Word {
+	reviewWord := *word
+
+	reviewWord.CardID = c.ID
+	reviewWord.CardType = c.CardType
+	reviewWord.Difficulty = c.Difficulty
+	reviewWord.NextReview = c.NextReview
+	reviewWord.ReviewCount = c.ReviewCount
+	reviewWord.CorrectAnswers = c.CorrectAnswers
+	reviewWord.IntervalDays = c.IntervalDays
+	reviewWord.LastReviewedAt = c.LastReviewedAt
+	reviewWord.Stability = c.Stability
+	reviewWord.FSRSDifficulty = c.FSRSDifficulty
+	reviewWord.Retrievability = c.Retrievability
+
+	return &reviewWord
+}
+
+// CardFromReview собирает карточку из слова, полученного через ForReview
+func CardFromReview(word *Word) *Card {
+	return &Card{
+		ID:             word.CardID,
+		WordID:         word.ID,
+		UserID:         word.UserID,
+		CardType:       word.CardType,
+		Difficulty:     word.Difficulty,
+		NextReview:     word.NextReview,
+		ReviewCount:    word.ReviewCount,
+		CorrectAnswers: word.CorrectAnswers,
+		IntervalDays:   word.IntervalDays,
+		LastReviewedAt: word.LastReviewedAt,
+		Stability:      word.Stability,
+		FSRSDifficulty: word.FSRSDifficulty,
+		Retrievability: word.Retrievability,
+		UpdatedAt:      word.UpdatedAt,
+	}
+}
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ClozeBlank - пропуск на месте изучаемого слова. Подчёркивания не используются,
+// потому что в Markdown они означают курсив.
+const ClozeBlank = "＿＿＿"
+
+type clozeToken struct {
+	text       string
+	start, end int
+}
+
+// BuildCloze заменяет изучаемое слово (или фразу) в примере пропуском.
+// Слово ищется сначала точно, затем по общей основе, чтобы находились формы
+// вроде books, studied или книгу. Возвращает предложение с пропуском и слово
+// в той форме, в которой оно стоит в примере.
+func BuildCloze(sentence, target string) (cloze, answer string, ok bool) {
+	tokens := tokenizeCloze(sentence)
+	targetWords := strings.Fields(NormalizeText(target))
+	if len(tokens) == 0 || len(targetWords) == 0 {
+		return "", "", false
+	}
+
+	for _, match := range []func(token, target string) bool{sameWord, sameStem} {
+		for i := 0; i+len(targetWords) <= len(tokens); i++ {
+			found := true
+			for j, targetWord := range targetWords {
+				if !match(tokens[i+j].text, targetWord) {
+					found = false
+					break
+				}
+			}
+
+			if found {
+				start, end := tokens[i].start, tokens[i+len(targetWords)-1].end
+				return sentence[:start] + ClozeBlank + sentence[end:], sentence[start:end], true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+func tokenizeCloze(sentence string) []clozeToken {
+	var tokens []clozeToken
+	start := -1
+
+	for i, r := range sentence {
+		isWordRune := unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\'' || r == '’'
+		if isWordRune && start < 0 {
+			start = i
+		}
+		if !isWordRune && start >= 0 {
+			tokens = append(tokens, clozeToken{text: sentence[start:i], start: start, end: i})
+			start = -1
+		}
+	}
+
+	if start >= 0 {
+		tokens = append(tokens, clozeToken{text: sentence[start:], start: start, end: len(sentence)})
+	}
+
+	return tokens
+}
+
+func sameWord(token, target string) bool {
+	return FoldDiacritics(NormalizeText(token)) == FoldDiacritics(target)
+}
+
+// sameStem - упрощённый стемминг: у слов должна совпадать основа, то есть всё слово
+// без 1-2 последних букв (book → books, study → studied, книга → книгу).
+// Короткие слова сравниваются только точно, иначе пропуск найдётся где попало.
+func sameStem(token, target string) bool {
+	a := []rune(FoldDiacritics(NormalizeText(token)))
+	b := []rune(FoldDiacritics(target))
+
+	if len(b) < 3 || len(a) > len(b)+4 {
+		return false
+	}
+
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	return prefix >= max(3, len(b)-2)
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+const MaxDeckNameLength = 50
+
+// Deck - колода, в которую пользователь группирует слова (например, «Путешествия» или «Работа»)
+type Deck struct {
+	ID        int       `json:"id"`
+	UserID    int64     `json:"user_id"`
+	Name      string    `json:"name"`
+	WordCount int       `json:"word_count"` // заполняется только при загрузке списка колод
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewDeck(userID int64, name string) *Deck {
+	now := time.Now()
+	return &Deck{
+		UserID:    userID,
+		Name:      strings.TrimSpace(name),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+func (d *Deck) Rename(name string) {
+	d.Name = strings.TrimSpace(name)
+	d.UpdatedAt = time.Now()
+}
+
+// HasName сравнивает имя колоды без учёта регистра
+func (d *Deck) HasName(name string) bool {
+	return strings.EqualFold(d.Name, strings.TrimSpace(name))
+}
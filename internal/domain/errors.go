@@ -0,0 +1,12 @@
+package domain
+
+import "errors"
+
+// Ошибки доступа к словам: репозитории и сервисы оборачивают их, проверять - через errors.Is
+var (
+	ErrWordNotFound  = errors.New("word not found")
+	ErrWordForbidden = errors.New("word belongs to another user")
+)
+
+// ErrDuplicateWord - такое или очень похожее слово уже есть в словаре пользователя
+var ErrDuplicateWord = errors.New("word already exists")
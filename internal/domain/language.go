@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"strings"
+
+	"ivanSaichkin/language-bot/internal/constants"
+)
+
+type Language struct {
+	Code string
+	Name string
+	Flag string
+}
+
+// SupportedLanguages - языки, которые можно выбрать для изучения.
+// Чтобы добавить язык, достаточно дописать его сюда.
+var SupportedLanguages = []Language{
+	{Code: constants.LanguageEnglish, Name: "Английский", Flag: "🇬🇧"},
+	{Code: constants.LanguageGerman, Name: "Немецкий", Flag: "🇩🇪"},
+	{Code: constants.LanguageFrench, Name: "Французский", Flag: "🇫🇷"},
+	{Code: constants.LanguageSpanish, Name: "Испанский", Flag: "🇪🇸"},
+	{Code: constants.LanguageItalian, Name: "Итальянский", Flag: "🇮🇹"},
+	{Code: constants.LanguageRussian, Name: "Русский", Flag: "🇷🇺"},
+}
+
+// FindLanguage ищет язык по коду (de) или названию (немецкий) без учёта регистра
+func FindLanguage(input string) (Language, bool) {
+	input = strings.TrimSpace(input)
+	for _, language := range SupportedLanguages {
+		if strings.EqualFold(language.Code, input) || strings.EqualFold(language.Name, input) {
+			return language, true
+		}
+	}
+
+	return Language{}, false
+}
+
+func IsSupportedLanguage(code string) bool {
+	for _, language := range SupportedLanguages {
+		if language.Code == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (l Language) Label() string {
+	return l.Flag + " " + l.Name
+}
+
+// LanguageLabel возвращает название языка с флагом или сам код, если язык неизвестен
+func LanguageLabel(code string) string {
+	if language, ok := FindLanguage(code); ok {
+		return language.Label()
+	}
+
+	return code
+}
+
+// LanguageCodes перечисляет коды поддерживаемых языков через запятую
+func LanguageCodes() string {
+	codes := make([]string, 0, len(SupportedLanguages))
+	for _, language := range SupportedLanguages {
+		codes = append(codes, language.Code)
+	}
+
+	return strings.Join(codes, ", ")
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// MatchGame - игра «найди пары»: слева слова, справа их переводы в случайном порядке.
+// Пользователь выбирает слово, затем перевод; ошибки считаются для выбранного слова.
+// Переводы слов в игре не должны повторяться, иначе пару нельзя определить однозначно.
+type MatchGame struct {
+	ID          string    `json:"id"`
+	UserID      int64     `json:"user_id"`
+	Words       []*Word   `json:"words"`
+	Order       []int     `json:"order"` // Order[i] - номер слова, чей перевод стоит на i-й позиции
+	Matched     []bool    `json:"matched"`
+	Mistakes    []int     `json:"mistakes"`
+	Selected    int       `json:"selected"` // -1, если слово не выбрано
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	IsCompleted bool      `json:"is_completed"`
+}
+
+func NewMatchGame(userID int64, words []*Word) *MatchGame {
+	order := rand.Perm(len(words))
+
+	return &MatchGame{
+		ID:        fmt.Sprintf("match-%d-%d", userID, time.Now().UnixNano()),
+		UserID:    userID,
+		Words:     words,
+		Order:     order,
+		Matched:   make([]bool, len(words)),
+		Mistakes:  make([]int, len(words)),
+		Selected:  -1,
+		StartTime: time.Now(),
+	}
+}
+
+// TranslationAt возвращает перевод, стоящий на указанной позиции
+func (g *MatchGame) TranslationAt(position int) string {
+	return g.Words[g.Order[position]].Translation
+}
+
+// IsTranslationMatched - перевод на позиции уже сопоставлен со словом
+func (g *MatchGame) IsTranslationMatched(position int) bool {
+	return g.Matched[g.Order[position]]
+}
+
+func (g *MatchGame) Select(wordIndex int) bool {
+	if g.IsCompleted || wordIndex < 0 || wordIndex >= len(g.Words) || g.Matched[wordIndex] {
+		return false
+	}
+
+	g.Selected = wordIndex
+	return true
+}
+
+func (g *MatchGame) HasSelection() bool {
+	return g.Selected >= 0
+}
+
+// Match сопоставляет выбранное слово с переводом на позиции
+func (g *MatchGame) Match(position int) (isCorrect bool) {
+	if !g.HasSelection() || position < 0 || position >= len(g.Order) || g.IsTranslationMatched(position) {
+		return false
+	}
+
+	wordIndex := g.Selected
+	g.Selected = -1
+
+	if g.Order[position] != wordIndex {
+		g.Mistakes[wordIndex]++
+		return false
+	}
+
+	g.Matched[wordIndex] = true
+	if g.MatchedCount() == len(g.Words) {
+		g.Complete()
+	}
+
+	return true
+}
+
+func (g *MatchGame) MatchedCount() int {
+	count := 0
+	for _, matched := range g.Matched {
+		if matched {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (g *MatchGame) TotalMistakes() int {
+	total := 0
+	for _, mistakes := range g.Mistakes {
+		total += mistakes
+	}
+
+	return total
+}
+
+func (g *MatchGame) Complete() {
+	g.IsCompleted = true
+	g.EndTime = time.Now()
+	g.Selected = -1
+}
+
+func (g *MatchGame) GetDuration() time.Duration {
+	if g.EndTime.IsZero() {
+		return time.Since(g.StartTime)
+	}
+
+	return g.EndTime.Sub(g.StartTime)
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"fmt"
+	"ivanSaichkin/language-bot/internal/constants"
+	"time"
+)
+
+// Quiz - проверочный тест по словам. В отличие от сессии повторения
+// не зависит от сроков повторения и не меняет расписание слов.
+type Quiz struct {
+	ID           string          `json:"id"`
+	UserID       int64           `json:"user_id"`
+	Questions    []*QuizQuestion `json:"questions"`
+	CurrentIndex int             `json:"current_index"`
+	Correct      int             `json:"correct"`
+	StartTime    time.Time       `json:"start_time"`
+	EndTime      time.Time       `json:"end_time"`
+	IsCompleted  bool            `json:"is_completed"`
+}
+
+// QuizQuestion - вопрос теста. Word может быть обратной карточкой (см. Word.IsReverse),
+// а при заполненных Choices ответ выбирается из вариантов.
+type QuizQuestion struct {
+	Word      *Word    `json:"word"`
+	Choices   []string `json:"choices,omitempty"`
+	Answer    string   `json:"answer"`
+	IsCorrect bool     `json:"is_correct"`
+}
+
+func NewQuiz(userID int64, questions []*QuizQuestion) *Quiz {
+	return &Quiz{
+		ID:        fmt.Sprintf("quiz-%d-%d", userID, time.Now().UnixNano()),
+		UserID:    userID,
+		Questions: questions,
+		StartTime: time.Now(),
+	}
+}
+
+// NewQuizQuestion создаёт вопрос; reverse - спрашивать оригинал по переводу
+func NewQuizQuestion(word *Word, reverse bool) *QuizQuestion {
+	questionWord := *word
+	if reverse {
+		questionWord.CardType = constants.CardTypeReverse
+	}
+
+	return &QuizQuestion{Word: &questionWord}
+}
+
+func (q *QuizQuestion) HasChoices() bool {
+	return len(q.Choices) > 0
+}
+
+func (q *Quiz) CurrentQuestion() *QuizQuestion {
+	if q.IsCompleted || q.CurrentIndex >= len(q.Questions) {
+		return nil
+	}
+
+	return q.Questions[q.CurrentIndex]
+}
+
+func (q *Quiz) Answer(answer string, isCorrect bool) {
+	question := q.CurrentQuestion()
+	if question == nil {
+		return
+	}
+
+	question.Answer = answer
+	question.IsCorrect = isCorrect
+	if isCorrect {
+		q.Correct++
+	}
+
+	q.CurrentIndex++
+	if q.CurrentIndex >= len(q.Questions) {
+		q.Complete()
+	}
+}
+
+func (q *Quiz) Complete() {
+	q.IsCompleted = true
+	q.EndTime = time.Now()
+}
+
+func (q *Quiz) GetProgress() (current int, total int) {
+	return q.CurrentIndex + 1, len(q.Questions)
+}
+
+// Answered возвращает количество вопросов, на которые дан ответ
+func (q *Quiz) Answered() int {
+	return q.CurrentIndex
+}
+
+func (q *Quiz) GetScore() float64 {
+	if q.Answered() == 0 {
+		return 0
+	}
+
+	return float64(q.Correct) / float64(q.Answered()) * 100
+}
+
+// MissedQuestions возвращает вопросы, на которые дан неверный ответ
+func (q *Quiz) MissedQuestions() []*QuizQuestion {
+	var missed []*QuizQuestion
+	for _, question := range q.Questions[:q.Answered()] {
+		if !question.IsCorrect {
+			missed = append(missed, question)
+		}
+	}
+
+	return missed
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"fmt"
+	"ivanSaichkin/language-bot/internal/constants"
 	"time"
 )
 
@@ -15,6 +16,25 @@ type ReviewSession struct {
 	StartTime      time.Time `json:"start_time"`
 	EndTime        time.Time `json:"end_time"`
 	IsCompleted    bool      `json:"is_completed"`
+	Mode           string    `json:"mode"`
+	// Варианты ответа для текущего вопроса в режиме выбора
+	Choices []string `json:"choices,omitempty"`
+	// Момент показа текущего вопроса, от него считается время ответа
+	QuestionShownAt time.Time     `json:"question_shown_at"`
+	LastAnswer      *AnsweredWord `json:"last_answer,omitempty"`
+}
+
+// AnsweredWord хранит состояние слова до последнего ответа,
+// чтобы пользователь мог переоценить ответ кнопками.
+type AnsweredWord struct {
+	WordID    int         `json:"word_id"`
+	LogID     int64       `json:"log_id"`
+	Index     int         `json:"index"`
+	Previous  Word        `json:"previous"`
+	Grade     RecallGrade `json:"grade"`
+	IsCorrect bool        `json:"is_correct"`
+	// Ответ выбран из вариантов, а не введён
+	Choice bool `json:"choice,omitempty"`
 }
 
 type ReviewResult struct {
@@ -23,9 +43,56 @@ type ReviewResult struct {
 	NextInterval  time.Duration `json:"next_interval"`
 	NewDifficulty float64       `json:"new_difficulty"`
 	Quality       int           `json:"quality"` // Качество ответа (0-5)
+
+	// Заполняются только планировщиком FSRS
+	NewStability      float64 `json:"new_stability,omitempty"`
+	NewFSRSDifficulty float64 `json:"new_fsrs_difficulty,omitempty"`
+	Retrievability    float64 `json:"retrievability,omitempty"`
 }
 
-func NewReviewSession(userID int64, words []*Word) *ReviewSession {
+// RecallGrade - самооценка припоминания после показа ответа
+type RecallGrade int
+
+const (
+	GradeAuto RecallGrade = iota // оценка выставляется автоматически по ответу
+	GradeAgain
+	GradeHard
+	GradeGood
+	GradeEasy
+)
+
+// Quality переводит оценку в шкалу качества SM-2 (0-5)
+func (g RecallGrade) Quality() int {
+	switch g {
+	case GradeHard:
+		return 3
+	case GradeGood:
+		return 4
+	case GradeEasy:
+		return 5
+	default:
+		return 1
+	}
+}
+
+func (g RecallGrade) IsValid() bool {
+	return g >= GradeAgain && g <= GradeEasy
+}
+
+func GradeFromQuality(quality int) RecallGrade {
+	switch {
+	case quality >= 5:
+		return GradeEasy
+	case quality == 4:
+		return GradeGood
+	case quality == 3:
+		return GradeHard
+	default:
+		return GradeAgain
+	}
+}
+
+func NewReviewSession(userID int64, words []*Word, mode string) *ReviewSession {
 	if len(words) > 20 {
 		words = words[:20]
 	}
@@ -39,6 +106,7 @@ func NewReviewSession(userID int64, words []*Word) *ReviewSession {
 		TotalQuestions: len(words),
 		StartTime:      time.Now(),
 		IsCompleted:    false,
+		Mode:           mode,
 	}
 }
 
@@ -64,12 +132,35 @@ func (rs *ReviewSession) Answer(isCorrect bool) {
 		rs.CorrectAnswers++
 	}
 
+	rs.Choices = nil
 	rs.CurrentIndex++
 	if rs.CurrentIndex >= len(rs.Words) {
 		rs.Complete()
 	}
 }
 
+func (rs *ReviewSession) IsChoiceMode() bool {
+	return rs.Mode == constants.ReviewModeChoice
+}
+
+// HasChoices сообщает, что текущий вопрос задан с вариантами ответа
+func (rs *ReviewSession) HasChoices() bool {
+	return len(rs.Choices) > 0
+}
+
+func (rs *ReviewSession) MarkQuestionShown() {
+	rs.QuestionShownAt = time.Now()
+}
+
+// ResponseTime возвращает время с момента показа текущего вопроса
+func (rs *ReviewSession) ResponseTime() time.Duration {
+	if rs.QuestionShownAt.IsZero() {
+		return 0
+	}
+
+	return time.Since(rs.QuestionShownAt)
+}
+
 func (rs *ReviewSession) GetProgress() (current int, total int) {
 	return rs.CurrentIndex + 1, len(rs.Words)
 }
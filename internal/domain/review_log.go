@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"ivanSaichkin/language-bot/internal/constants"
+	"time"
+)
+
+// ReviewLog - запись об одном ответе пользователя
+type ReviewLog struct {
+	ID                 int64         `json:"id"`
+	WordID             int           `json:"word_id"`
+	CardType           string        `json:"card_type"`
+	Mode               string        `json:"mode"`
+	UserID             int64         `json:"user_id"`
+	ReviewedAt         time.Time     `json:"reviewed_at"`
+	Answer             string        `json:"answer"`
+	IsCorrect          bool          `json:"is_correct"`
+	Quality            int           `json:"quality"`
+	PreviousInterval   float64       `json:"previous_interval"` // в днях
+	NextInterval       float64       `json:"next_interval"`     // в днях
+	PreviousDifficulty float64       `json:"previous_difficulty"`
+	NewDifficulty      float64       `json:"new_difficulty"`
+	ResponseTime       time.Duration `json:"response_time"`
+}
+
+func NewReviewLog(previous *Word, answer string, result *ReviewResult, responseTime time.Duration) *ReviewLog {
+	// Для FSRS в журнал пишется сложность FSRS, для SM-2 - коэффициент лёгкости
+	previousDifficulty, newDifficulty := previous.Difficulty, result.NewDifficulty
+	if result.NewStability > 0 {
+		previousDifficulty, newDifficulty = previous.FSRSDifficulty, result.NewFSRSDifficulty
+	}
+
+	return &ReviewLog{
+		WordID:             previous.ID,
+		CardType:           previous.GetCardType(),
+		Mode:               constants.ReviewModeTyping,
+		UserID:             previous.UserID,
+		ReviewedAt:         time.Now(),
+		Answer:             answer,
+		IsCorrect:          result.IsCorrect,
+		Quality:            result.Quality,
+		PreviousInterval:   previous.IntervalDays,
+		NextInterval:       result.NextInterval.Hours() / 24,
+		PreviousDifficulty: previousDifficulty,
+		NewDifficulty:      newDifficulty,
+		ResponseTime:       responseTime,
+	}
+}
+
+// NewGameLog - запись об ответе в игре. Расписание слова не меняется,
+// поэтому интервал и сложность до и после совпадают.
+func NewGameLog(word *Word, mode, answer string, isCorrect bool, responseTime time.Duration) *ReviewLog {
+	difficulty := word.Difficulty
+	if word.Stability > 0 {
+		difficulty = word.FSRSDifficulty
+	}
+
+	return &ReviewLog{
+		WordID:             word.ID,
+		CardType:           word.GetCardType(),
+		Mode:               mode,
+		UserID:             word.UserID,
+		ReviewedAt:         time.Now(),
+		Answer:             answer,
+		IsCorrect:          isCorrect,
+		PreviousInterval:   word.IntervalDays,
+		NextInterval:       word.IntervalDays,
+		PreviousDifficulty: difficulty,
+		NewDifficulty:      difficulty,
+		ResponseTime:       responseTime,
+	}
+}
+
+// ApplyResult обновляет запись после переоценки ответа
+func (l *ReviewLog) ApplyResult(result *ReviewResult) {
+	l.IsCorrect = result.IsCorrect
+	l.Quality = result.Quality
+	l.NextInterval = result.NextInterval.Hours() / 24
+	l.NewDifficulty = result.NewDifficulty
+	if result.NewStability > 0 {
+		l.NewDifficulty = result.NewFSRSDifficulty
+	}
+}
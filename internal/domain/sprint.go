@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+const (
+	SprintDuration = 60 * time.Second
+
+	sprintBasePoints    = 10
+	sprintComboStep     = 4 // каждые 4 верных ответа подряд удваивают множитель
+	sprintMaxMultiplier = 8
+)
+
+// Sprint - игра на скорость: пользователь за минуту отвечает, верна ли пара
+// «слово = перевод». Ответы не влияют на расписание повторений.
+type Sprint struct {
+	ID          string      `json:"id"`
+	UserID      int64       `json:"user_id"`
+	Words       []*Word     `json:"words"`
+	Pair        *SprintPair `json:"pair"`
+	Score       int         `json:"score"`
+	Combo       int         `json:"combo"`
+	MaxCombo    int         `json:"max_combo"`
+	Correct     int         `json:"correct"`
+	Answered    int         `json:"answered"`
+	StartTime   time.Time   `json:"start_time"`
+	EndTime     time.Time   `json:"end_time"`
+	IsCompleted bool        `json:"is_completed"`
+
+	nextWord int
+}
+
+// SprintPair - показанная пара; IsMatch - перевод действительно относится к слову
+type SprintPair struct {
+	Word        *Word     `json:"word"`
+	Translation string    `json:"translation"`
+	IsMatch     bool      `json:"is_match"`
+	ShownAt     time.Time `json:"shown_at"`
+}
+
+func NewSprint(userID int64, words []*Word) *Sprint {
+	return &Sprint{
+		ID:        fmt.Sprintf("sprint-%d-%d", userID, time.Now().UnixNano()),
+		UserID:    userID,
+		Words:     words,
+		StartTime: time.Now(),
+	}
+}
+
+func (s *Sprint) Deadline() time.Time {
+	return s.StartTime.Add(SprintDuration)
+}
+
+func (s *Sprint) TimeLeft() time.Duration {
+	left := time.Until(s.Deadline())
+	if left < 0 {
+		return 0
+	}
+
+	return left
+}
+
+func (s *Sprint) IsExpired() bool {
+	return !time.Now().Before(s.Deadline())
+}
+
+// NextWord возвращает следующее слово; когда слова заканчиваются, они перемешиваются заново
+func (s *Sprint) NextWord() *Word {
+	if len(s.Words) == 0 {
+		return nil
+	}
+
+	if s.nextWord >= len(s.Words) {
+		rand.Shuffle(len(s.Words), func(i, j int) {
+			s.Words[i], s.Words[j] = s.Words[j], s.Words[i]
+		})
+		s.nextWord = 0
+	}
+
+	word := s.Words[s.nextWord]
+	s.nextWord++
+	return word
+}
+
+func (s *Sprint) ShowPair(word *Word, translation string, isMatch bool) {
+	s.Pair = &SprintPair{
+		Word:        word,
+		Translation: translation,
+		IsMatch:     isMatch,
+		ShownAt:     time.Now(),
+	}
+}
+
+// Multiplier - множитель очков за текущую серию верных ответов: x1, x2, x4, x8
+func (s *Sprint) Multiplier() int {
+	multiplier := 1
+	for combo := s.Combo; combo >= sprintComboStep && multiplier < sprintMaxMultiplier; combo -= sprintComboStep {
+		multiplier *= 2
+	}
+
+	return multiplier
+}
+
+// Answer засчитывает ответ на текущую пару и возвращает начисленные очки.
+// Ошибка сбрасывает серию, а вместе с ней и множитель.
+func (s *Sprint) Answer(isMatch bool) (isCorrect bool, points int) {
+	if s.Pair == nil || s.IsCompleted {
+		return false, 0
+	}
+
+	s.Answered++
+	isCorrect = isMatch == s.Pair.IsMatch
+
+	if !isCorrect {
+		s.Combo = 0
+		return false, 0
+	}
+
+	points = sprintBasePoints * s.Multiplier()
+	s.Score += points
+	s.Correct++
+	s.Combo++
+	if s.Combo > s.MaxCombo {
+		s.MaxCombo = s.Combo
+	}
+
+	return true, points
+}
+
+func (s *Sprint) Complete() {
+	s.IsCompleted = true
+	s.EndTime = time.Now()
+	s.Pair = nil
+}
+
+func (s *Sprint) GetAccuracy() float64 {
+	if s.Answered == 0 {
+		return 0
+	}
+
+	return float64(s.Correct) / float64(s.Answered) * 100
+}
@@ -10,6 +10,7 @@ type UserStats struct {
 	TotalCorrect   int       `json:"total_correct"`
 	StreakDays     int       `json:"streak_days"`
 	MaxStreakDays  int       `json:"max_streak_days"`
+	SprintBest     int       `json:"sprint_best"`
 	LastReviewDate time.Time `json:"last_review_date"`
 	TotalTime      int64     `json:"total_time"`
 	CreatedAt      time.Time `json:"created_at"`
@@ -45,6 +46,17 @@ func (us *UserStats) AddReview(isCorrect bool, duration time.Duration) {
 	us.updateStreak()
 }
 
+// RecordSprintScore обновляет рекорд спринта и сообщает, побит ли он
+func (us *UserStats) RecordSprintScore(score int) bool {
+	if score <= us.SprintBest {
+		return false
+	}
+
+	us.SprintBest = score
+	us.UpdatedAt = time.Now()
+	return true
+}
+
 func (us *UserStats) UpdateWordCount(total, learned int) {
 	us.TotalWords = total
 	us.LearnedWords = learned
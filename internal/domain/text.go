@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeText приводит строку к форме NFC и нижнему регистру, убирает пунктуацию
+// и лишние пробелы. Диакритика сохраняется: «café», набранное готовым символом
+// или буквой с комбинируемым знаком, даёт одну и ту же строку.
+func NormalizeText(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(norm.NFC.String(s)) {
+		if unicode.IsPunct(r) || unicode.IsSymbol(r) {
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// Буквы, которые не раскладываются в NFD на основу и знак
+var undecomposableReplacer = strings.NewReplacer(
+	"ł", "l", "Ł", "L", "ø", "o", "Ø", "O", "đ", "d", "Đ", "D", "ħ", "h", "ı", "i",
+	"ß", "ss", "œ", "oe", "Œ", "OE", "æ", "ae", "Æ", "AE",
+)
+
+// FoldDiacritics убирает диакритику: раскладывает буквы в NFD и отбрасывает комбинируемые знаки.
+// Кроме «й»: это отдельная буква, а не «и» с ударением.
+func FoldDiacritics(s string) string {
+	var b strings.Builder
+	var prev rune
+	for _, r := range norm.NFD.String(s) {
+		if unicode.Is(unicode.Mn, r) && !(r == '\u0306' && (prev == 'и' || prev == 'И')) {
+			continue
+		}
+		b.WriteRune(r)
+		prev = r
+	}
+
+	return undecomposableReplacer.Replace(norm.NFC.String(b.String()))
+}
+
+// SearchKey приводит текст к виду для поиска и сравнения слов:
+// без регистра, знаков препинания и диакритики
+func SearchKey(text string) string {
+	return FoldDiacritics(NormalizeText(text))
+}
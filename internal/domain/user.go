@@ -1,34 +1,48 @@
 package domain
 
 import (
+	"fmt"
 	"ivanSaichkin/language-bot/internal/constants"
+	"strings"
 	"time"
 )
 
 type User struct {
-	ID           int64               `json:"id"`
-	Username     string              `json:"username"`
-	FirstName    string              `json:"first_name"`
-	LastName     string              `json:"last_name"`
-	LanguageCode string              `json:"language_code"`
-	State        constants.UserState `json:"state"`
-	DailyGoal    int                 `json:"daily_goal"`
-	CreatedAt    time.Time           `json:"created_at"`
-	UpdatedAt    time.Time           `json:"updated_at"`
+	ID               int64               `json:"id"`
+	Username         string              `json:"username"`
+	FirstName        string              `json:"first_name"`
+	LastName         string              `json:"last_name"`
+	LanguageCode     string              `json:"language_code"`
+	State            constants.UserState `json:"state"`
+	DailyGoal        int                 `json:"daily_goal"`
+	Scheduler        string              `json:"scheduler"`
+	DesiredRetention float64             `json:"desired_retention"`
+	Timezone         string              `json:"timezone"`
+	AnswerStrictness string              `json:"answer_strictness"`
+	CardDirections   string              `json:"card_directions"`
+	CurrentDeckID    int                 `json:"current_deck_id"` // 0 - новые слова добавляются без колоды
+	StudyLanguage    string              `json:"study_language"`
+	CreatedAt        time.Time           `json:"created_at"`
+	UpdatedAt        time.Time           `json:"updated_at"`
 }
 
 func NewUser(userID int64, username, firstName, lastName, languageCode string) *User {
 	now := time.Now()
 	return &User{
-		ID:           userID,
-		Username:     username,
-		FirstName:    firstName,
-		LastName:     lastName,
-		LanguageCode: languageCode,
-		State:        constants.StateDefault,
-		DailyGoal:    10,
-		CreatedAt:    now,
-		UpdatedAt:    now,
+		ID:               userID,
+		Username:         username,
+		FirstName:        firstName,
+		LastName:         lastName,
+		LanguageCode:     languageCode,
+		State:            constants.StateDefault,
+		DailyGoal:        10,
+		Scheduler:        constants.SchedulerSM2,
+		DesiredRetention: 0.9,
+		AnswerStrictness: constants.StrictnessNormal,
+		CardDirections:   constants.CardDirectionsForward,
+		StudyLanguage:    constants.LanguageEnglish,
+		CreatedAt:        now,
+		UpdatedAt:        now,
 	}
 }
 
@@ -49,3 +63,112 @@ func (u *User) SetDailyGoal(goal int) {
 	u.DailyGoal = goal
 	u.UpdatedAt = time.Now()
 }
+
+func (u *User) SetScheduler(scheduler string, desiredRetention float64) {
+	if desiredRetention < 0.7 {
+		desiredRetention = 0.7
+	}
+
+	if desiredRetention > 0.97 {
+		desiredRetention = 0.97
+	}
+
+	u.Scheduler = scheduler
+	u.DesiredRetention = desiredRetention
+	u.UpdatedAt = time.Now()
+}
+
+// Location возвращает часовой пояс пользователя или часовой пояс сервера, если он не задан
+func (u *User) Location() *time.Location {
+	if u.Timezone == "" {
+		return time.Local
+	}
+
+	loc, err := ParseTimezone(u.Timezone)
+	if err != nil {
+		return time.Local
+	}
+
+	return loc
+}
+
+// StartOfDay возвращает начало текущих суток в часовом поясе пользователя
+func (u *User) StartOfDay(now time.Time) time.Time {
+	local := now.In(u.Location())
+	year, month, day := local.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, local.Location())
+}
+
+func (u *User) SetAnswerStrictness(strictness string) {
+	u.AnswerStrictness = strictness
+	u.UpdatedAt = time.Now()
+}
+
+func (u *User) SetCardDirections(directions string) {
+	u.CardDirections = directions
+	u.UpdatedAt = time.Now()
+}
+
+func (u *User) SetCurrentDeck(deckID int) {
+	u.CurrentDeckID = deckID
+	u.UpdatedAt = time.Now()
+}
+
+// SetStudyLanguage выбирает язык, на котором добавляются и повторяются слова
+func (u *User) SetStudyLanguage(code string) error {
+	if !IsSupportedLanguage(code) {
+		return fmt.Errorf("unsupported language: %s", code)
+	}
+
+	u.StudyLanguage = code
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// WantsReverseCards сообщает, нужно ли создавать обратные карточки для новых слов
+func (u *User) WantsReverseCards() bool {
+	return u.CardDirections == constants.CardDirectionsBoth
+}
+
+func (u *User) SetTimezone(timezone string) error {
+	if _, err := ParseTimezone(timezone); err != nil {
+		return err
+	}
+
+	u.Timezone = timezone
+	u.UpdatedAt = time.Now()
+	return nil
+}
+
+// ParseTimezone понимает названия IANA (Europe/Moscow) и смещения вида +3, -05:30, UTC+3
+func ParseTimezone(timezone string) (*time.Location, error) {
+	tz := strings.TrimSpace(timezone)
+	offset := strings.TrimPrefix(strings.TrimPrefix(strings.ToUpper(tz), "UTC"), "GMT")
+
+	if offset != "" && (offset[0] == '+' || offset[0] == '-') {
+		sign := 1
+		if offset[0] == '-' {
+			sign = -1
+		}
+
+		var hours, minutes int
+		if _, err := fmt.Sscanf(strings.Replace(offset[1:], ":", " ", 1), "%d %d", &hours, &minutes); err != nil {
+			if _, err := fmt.Sscanf(offset[1:], "%d", &hours); err != nil {
+				return nil, fmt.Errorf("invalid timezone offset: %s", timezone)
+			}
+		}
+
+		if hours < 0 || hours > 14 || minutes < 0 || minutes >= 60 {
+			return nil, fmt.Errorf("invalid timezone offset: %s", timezone)
+		}
+
+		return time.FixedZone(tz, sign*(hours*3600+minutes*60)), nil
+	}
+
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone: %s", timezone)
+	}
+
+	return loc, nil
+}
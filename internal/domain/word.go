@@ -1,7 +1,9 @@
 package domain
 
 import (
+	"fmt"
 	"ivanSaichkin/language-bot/internal/constants"
+	"strings"
 	"time"
 )
 
@@ -10,15 +12,28 @@ type Word struct {
 	UserID         int64     `json:"user_id"`
 	Original       string    `json:"original"`
 	Translation    string    `json:"translation"`
+	Translations   []string  `json:"translations,omitempty"`
 	Language       string    `json:"language"`
 	PartOfSpeech   string    `json:"part_of_speech"`
 	Example        string    `json:"example"`
+	DeckID         int       `json:"deck_id"` // 0 - слово не входит ни в одну колоду
 	Difficulty     float64   `json:"difficulty"`
 	NextReview     time.Time `json:"next_review"`
 	ReviewCount    int       `json:"review_count"`
 	CorrectAnswers int       `json:"correct_answers"`
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
+
+	// Состояние планировщика: фактический интервал и параметры FSRS
+	IntervalDays   float64   `json:"interval_days"`
+	LastReviewedAt time.Time `json:"last_reviewed_at"`
+	Stability      float64   `json:"stability"`
+	FSRSDifficulty float64   `json:"fsrs_difficulty"`
+	Retrievability float64   `json:"retrievability"`
+
+	// Заполняются, если слово в сессии представляет дополнительную карточку
+	CardID   int    `json:"card_id,omitempty"`
+	CardType string `json:"card_type,omitempty"`
 }
 
 func NewWord(userID int64, original, translation, language string) *Word {
@@ -28,6 +43,7 @@ func NewWord(userID int64, original, translation, language string) *Word {
 		UserID:         userID,
 		Original:       original,
 		Translation:    translation,
+		Translations:   []string{translation},
 		Language:       language,
 		PartOfSpeech:   constants.PartOfSpeechNoun,
 		Difficulty:     2.5,
@@ -39,6 +55,191 @@ func NewWord(userID int64, original, translation, language string) *Word {
 	}
 }
 
+// SetTranslations задаёт список допустимых переводов; первый становится основным
+func (w *Word) SetTranslations(translations []string) {
+	var unique []string
+	seen := make(map[string]bool)
+
+	for _, translation := range translations {
+		translation = strings.TrimSpace(translation)
+		key := NormalizeText(translation)
+		if key == "" || seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		unique = append(unique, translation)
+	}
+
+	if len(unique) == 0 {
+		return
+	}
+
+	w.Translations = unique
+	w.Translation = unique[0]
+}
+
+// AcceptedTranslations возвращает все переводы, которые засчитываются как верный ответ
+func (w *Word) AcceptedTranslations() []string {
+	if len(w.Translations) == 0 {
+		return []string{w.Translation}
+	}
+
+	return w.Translations
+}
+
+func (w *Word) DisplayTranslation() string {
+	return strings.Join(w.AcceptedTranslations(), ", ")
+}
+
+// IsReverse сообщает, что в вопросе показывается перевод, а ответом служит оригинал
+func (w *Word) IsReverse() bool {
+	return w.CardType == constants.CardTypeReverse
+}
+
+// IsCloze сообщает, что карточка - пример с пропуском. Если слово больше
+// не находится в примере (пример изменили), карточка показывается как прямая.
+func (w *Word) IsCloze() bool {
+	if w.CardType != constants.CardTypeCloze {
+		return false
+	}
+
+	_, _, ok := w.Cloze()
+	return ok
+}
+
+// Cloze возвращает пример с пропуском и пропущенную форму слова
+func (w *Word) Cloze() (sentence, answer string, ok bool) {
+	return BuildCloze(w.Example, w.Original)
+}
+
+// GetCardType возвращает тип карточки; обычное слово - прямая карточка
+func (w *Word) GetCardType() string {
+	if w.CardType == "" {
+		return constants.CardTypeForward
+	}
+
+	return w.CardType
+}
+
+// Prompt - текст, который показывается в вопросе
+func (w *Word) Prompt() string {
+	if w.IsReverse() {
+		return w.DisplayTranslation()
+	}
+
+	if w.CardType == constants.CardTypeCloze {
+		if sentence, _, ok := w.Cloze(); ok {
+			return sentence
+		}
+	}
+
+	return w.Original
+}
+
+// AcceptedAnswers возвращает ответы, которые засчитываются для этой карточки
+func (w *Word) AcceptedAnswers() []string {
+	if w.IsReverse() {
+		return []string{w.Original}
+	}
+
+	if w.CardType == constants.CardTypeCloze {
+		if _, answer, ok := w.Cloze(); ok {
+			return []string{answer}
+		}
+	}
+
+	return w.AcceptedTranslations()
+}
+
+func (w *Word) DisplayAnswer() string {
+	if w.IsReverse() {
+		return w.Original
+	}
+
+	if w.CardType == constants.CardTypeCloze {
+		if _, answer, ok := w.Cloze(); ok {
+			return answer
+		}
+	}
+
+	return w.DisplayTranslation()
+}
+
+var partsOfSpeech = map[string]string{
+	constants.PartOfSpeechNoun:      constants.PartOfSpeechNoun,
+	"n":                             constants.PartOfSpeechNoun,
+	constants.PartOfSpeechVerb:      constants.PartOfSpeechVerb,
+	"v":                             constants.PartOfSpeechVerb,
+	constants.PartOfSpeechAdjective: constants.PartOfSpeechAdjective,
+	"adj":                           constants.PartOfSpeechAdjective,
+	constants.PartOfSpeechAdverb:    constants.PartOfSpeechAdverb,
+	"adv":                           constants.PartOfSpeechAdverb,
+	constants.PartOfSpeechPhrase:    constants.PartOfSpeechPhrase,
+}
+
+// PartsOfSpeech - части речи в порядке показа пользователю
+var PartsOfSpeech = []string{
+	constants.PartOfSpeechNoun,
+	constants.PartOfSpeechVerb,
+	constants.PartOfSpeechAdjective,
+	constants.PartOfSpeechAdverb,
+	constants.PartOfSpeechPhrase,
+}
+
+// ParsePartOfSpeech понимает полные названия (noun) и сокращения (n, adj)
+func ParsePartOfSpeech(input string) (string, bool) {
+	pos, ok := partsOfSpeech[strings.ToLower(strings.TrimSpace(input))]
+	return pos, ok
+}
+
+// SetField меняет одно поле слова по его названию (constants.WordField*).
+// Переводы перечисляются через запятую, пустой пример или "-" удаляет пример.
+func (w *Word) SetField(field, value string) error {
+	value = strings.TrimSpace(value)
+
+	switch field {
+	case constants.WordFieldOriginal:
+		if value == "" {
+			return fmt.Errorf("original word cannot be empty")
+		}
+		w.Original = value
+
+	case constants.WordFieldTranslation:
+		translations := strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' })
+		if NormalizeText(strings.Join(translations, " ")) == "" {
+			return fmt.Errorf("translation cannot be empty")
+		}
+		w.SetTranslations(translations)
+
+	case constants.WordFieldExample:
+		if value == "-" {
+			value = ""
+		}
+		w.Example = value
+
+	case constants.WordFieldPartOfSpeech:
+		pos, ok := ParsePartOfSpeech(value)
+		if !ok {
+			return fmt.Errorf("unknown part of speech: %s", value)
+		}
+		w.PartOfSpeech = pos
+
+	case constants.WordFieldLanguage:
+		language, ok := FindLanguage(value)
+		if !ok {
+			return fmt.Errorf("unsupported language: %s", value)
+		}
+		w.Language = language.Code
+
+	default:
+		return fmt.Errorf("unknown word field: %s", field)
+	}
+
+	w.UpdatedAt = time.Now()
+	return nil
+}
+
 func (w *Word) WithPartOfSpeech(pos string) *Word {
 	w.PartOfSpeech = pos
 	return w
@@ -49,6 +250,20 @@ func (w *Word) WithExample(example string) *Word {
 	return w
 }
 
+// ApplyImportedSchedule переносит расписание из другой программы интервальных повторений.
+// ease - множитель интервала в терминах SM-2, он же Difficulty.
+func (w *Word) ApplyImportedSchedule(intervalDays, ease float64, nextReview time.Time, reviews, correct int) {
+	if ease >= 1.3 {
+		w.Difficulty = ease
+	}
+
+	w.IntervalDays = intervalDays
+	w.NextReview = nextReview
+	w.LastReviewedAt = nextReview.Add(-time.Duration(intervalDays * float64(24*time.Hour)))
+	w.ReviewCount = reviews
+	w.CorrectAnswers = max(correct, 0)
+}
+
 func (w *Word) IsDueForReview() bool {
 	return time.Now().After(w.NextReview) || time.Now().Equal(w.NextReview)
 }
@@ -65,6 +280,28 @@ func (w *Word) MarkReviewed(isCorrect bool, nextReview time.Time, newDifficulty
 	w.UpdatedAt = time.Now()
 }
 
+// MakeDue возвращает слово в очередь повторения
+func (w *Word) MakeDue() {
+	w.NextReview = time.Now()
+	w.UpdatedAt = time.Now()
+}
+
+// ResetProgress сбрасывает историю повторений, как у только что добавленного слова
+func (w *Word) ResetProgress() {
+	now := time.Now()
+
+	w.Difficulty = 2.5
+	w.NextReview = now
+	w.ReviewCount = 0
+	w.CorrectAnswers = 0
+	w.IntervalDays = 0
+	w.LastReviewedAt = time.Time{}
+	w.Stability = 0
+	w.FSRSDifficulty = 0
+	w.Retrievability = 0
+	w.UpdatedAt = now
+}
+
 func (w *Word) IsLearned() bool {
 	return w.CorrectAnswers >= 5
 }
@@ -78,6 +315,8 @@ func (w *Word) GetProgress() float64 {
 }
 
 func (w *Word) MarkReviewedWithResult(result *ReviewResult, nextReview time.Time) {
+	now := time.Now()
+
 	w.ReviewCount++
 	if result.IsCorrect {
 		w.CorrectAnswers++
@@ -86,5 +325,39 @@ func (w *Word) MarkReviewedWithResult(result *ReviewResult, nextReview time.Time
 	}
 	w.Difficulty = result.NewDifficulty
 	w.NextReview = nextReview
-	w.UpdatedAt = time.Now()
+	w.IntervalDays = result.NextInterval.Hours() / 24
+	w.LastReviewedAt = now
+	w.UpdatedAt = now
+
+	// SM-2 не ведёт состояние FSRS, поэтому обновляем его только если оно посчитано
+	if result.NewStability > 0 {
+		w.Stability = result.NewStability
+		w.FSRSDifficulty = result.NewFSRSDifficulty
+		w.Retrievability = result.Retrievability
+	}
+}
+
+// WordFilter ограничивает выборку слов колодой и языком; нулевые поля не фильтруют
+type WordFilter struct {
+	DeckID   int
+	Language string
+}
+
+func (f WordFilter) Matches(w *Word) bool {
+	if f.DeckID != 0 && w.DeckID != f.DeckID {
+		return false
+	}
+
+	return f.Language == "" || w.Language == f.Language
+}
+
+func FilterWords(words []*Word, filter WordFilter) []*Word {
+	var filtered []*Word
+	for _, word := range words {
+		if filter.Matches(word) {
+			filtered = append(filtered, word)
+		}
+	}
+
+	return filtered
 }
@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"ivanSaichkin/language-bot/internal/domain"
+)
+
+type cardRepository struct {
+	db *sql.DB
+}
+
+func NewCardRepository(db *sql.DB) CardRepository {
+	return &cardRepository{db: db}
+}
+
+func (r *cardRepository) Create(ctx context.Context, card *domain.Card) error {
+	query := `
+        INSERT INTO word_cards (word_id, user_id, card_type, difficulty, next_review, review_count, correct_answers,
+                                interval_days, last_reviewed_at, stability, fsrs_difficulty, retrievability,
+                                created_at, updated_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    `
+
+	result, err := r.db.ExecContext(ctx, query,
+		card.WordID,
+		card.UserID,
+		card.CardType,
+		card.Difficulty,
+		card.NextReview,
+		card.ReviewCount,
+		card.CorrectAnswers,
+		card.IntervalDays,
+		nullTime(card.LastReviewedAt),
+		card.Stability,
+		card.FSRSDifficulty,
+		card.Retrievability,
+		card.CreatedAt,
+		card.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create card: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	card.ID = int(id)
+	return nil
+}
+
+// Update сохраняет состояние карточки, если она принадлежит пользователю
+func (r *cardRepository) Update(ctx context.Context, userID int64, card *domain.Card) error {
+	query := `
+        UPDATE word_cards
+        SET difficulty = ?, next_review = ?, review_count = ?, correct_answers = ?, interval_days = ?,
+            last_reviewed_at = ?, stability = ?, fsrs_difficulty = ?, retrievability = ?, updated_at = ?
+        WHERE id = ? AND user_id = ?
+    `
+
+	result, err := r.db.ExecContext(ctx, query,
+		card.Difficulty,
+		card.NextReview,
+		card.ReviewCount,
+		card.CorrectAnswers,
+		card.IntervalDays,
+		nullTime(card.LastReviewedAt),
+		card.Stability,
+		card.FSRSDifficulty,
+		card.Retrievability,
+		time.Now(),
+		card.ID,
+		userID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update card: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("card not found")
+	}
+
+	return nil
+}
+
+// GetByWordID возвращает карточки слова; чужие карточки не возвращаются
+func (r *cardRepository) GetByWordID(ctx context.Context, userID int64, wordID int) ([]*domain.Card, error) {
+	query := `
+        SELECT id, word_id, user_id, card_type, difficulty, next_review, review_count, correct_answers,
+               interval_days, last_reviewed_at, stability, fsrs_difficulty, retrievability, created_at, updated_at
+        FROM word_cards WHERE word_id = ? AND user_id = ?
+        ORDER BY id ASC
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, wordID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word cards: %w", err)
+	}
+	defer rows.Close()
+
+	var cards []*domain.Card
+	for rows.Next() {
+		var card domain.Card
+		var lastReviewedAt sql.NullTime
+
+		err := rows.Scan(
+			&card.ID,
+			&card.WordID,
+			&card.UserID,
+			&card.CardType,
+			&card.Difficulty,
+			&card.NextReview,
+			&card.ReviewCount,
+			&card.CorrectAnswers,
+			&card.IntervalDays,
+			&lastReviewedAt,
+			&card.Stability,
+			&card.FSRSDifficulty,
+			&card.Retrievability,
+			&card.CreatedAt,
+			&card.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan card: %w", err)
+		}
+
+		if lastReviewedAt.Valid {
+			card.LastReviewedAt = lastReviewedAt.Time
+		}
+		cards = append(cards, &card)
+	}
+
+	return cards, nil
+}
+
+// GetCardsForReview возвращает карточки, которые пора повторить, в виде слов
+// с состоянием повторения карточки (см. Card.ForReview). Пустой cardType - карточки всех типов,
+// filter ограничивает колоду и язык слов.
+func (r *cardRepository) GetCardsForReview(ctx context.Context, userID int64, cardType string, filter domain.WordFilter, limit int) ([]*domain.Word, error) {
+	if limit <= 0 || limit > 50 {
+		limit = 10
+	}
+
+	query := `
+        SELECT w.id, w.user_id, w.original, w.translation, w.language, w.part_of_speech, w.example,
+               c.difficulty, c.next_review, c.review_count, c.correct_answers, w.created_at, c.updated_at,
+               c.interval_days, c.last_reviewed_at, c.stability, c.fsrs_difficulty, c.retrievability,
+               w.deck_id, c.id, c.card_type
+        FROM word_cards c
+        JOIN words w ON w.id = c.word_id
+        WHERE c.user_id = ? AND c.next_review <= ? AND (? = '' OR c.card_type = ?)
+          AND (? = 0 OR w.deck_id = ?) AND (? = '' OR w.language = ?)
+        ORDER BY c.next_review ASC
+        LIMIT ?
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, userID, time.Now(), cardType, cardType,
+		filter.DeckID, filter.DeckID, filter.Language, filter.Language, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cards for review: %w", err)
+	}
+	defer rows.Close()
+
+	var words []*domain.Word
+	for rows.Next() {
+		var word domain.Word
+		var lastReviewedAt sql.NullTime
+
+		err := rows.Scan(
+			&word.ID,
+			&word.UserID,
+			&word.Original,
+			&word.Translation,
+			&word.Language,
+			&word.PartOfSpeech,
+			&word.Example,
+			&word.Difficulty,
+			&word.NextReview,
+			&word.ReviewCount,
+			&word.CorrectAnswers,
+			&word.CreatedAt,
+			&word.UpdatedAt,
+			&word.IntervalDays,
+			&lastReviewedAt,
+			&word.Stability,
+			&word.FSRSDifficulty,
+			&word.Retrievability,
+			&word.DeckID,
+			&word.CardID,
+			&word.CardType,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan card: %w", err)
+		}
+
+		if lastReviewedAt.Valid {
+			word.LastReviewedAt = lastReviewedAt.Time
+		}
+		words = append(words, &word)
+	}
+
+	if err := loadTranslations(ctx, r.db, words); err != nil {
+		return nil, err
+	}
+
+	return words, nil
+}
+
+// GetWordIDsWithCard возвращает ID слов пользователя, у которых уже есть карточка этого типа
+func (r *cardRepository) GetWordIDsWithCard(ctx context.Context, userID int64, cardType string) (map[int]bool, error) {
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT word_id FROM word_cards WHERE user_id = ? AND card_type = ?`, userID, cardType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get words with cards: %w", err)
+	}
+	defer rows.Close()
+
+	wordIDs := make(map[int]bool)
+	for rows.Next() {
+		var wordID int
+		if err := rows.Scan(&wordID); err != nil {
+			return nil, fmt.Errorf("failed to scan word id: %w", err)
+		}
+		wordIDs[wordID] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate word ids: %w", err)
+	}
+
+	return wordIDs, nil
+}
+
+// CreateMissing создаёт карточки указанного типа для всех слов пользователя, у которых их ещё нет
+func (r *cardRepository) CreateMissing(ctx context.Context, userID int64, cardType string) (int, error) {
+	query := `
+        INSERT INTO word_cards (word_id, user_id, card_type, next_review, created_at, updated_at)
+        SELECT w.id, w.user_id, ?, ?, ?, ?
+        FROM words w
+        WHERE w.user_id = ?
+          AND NOT EXISTS (SELECT 1 FROM word_cards c WHERE c.word_id = w.id AND c.card_type = ?)
+    `
+
+	now := time.Now()
+	result, err := r.db.ExecContext(ctx, query, cardType, now, now, now, userID, cardType)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create missing cards: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return int(rows), nil
+}
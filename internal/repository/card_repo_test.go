@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"ivanSaichkin/language-bot/internal/domain"
+)
+
+func TestCardRepositoryOwnership(t *testing.T) {
+	db := newTestDB(t)
+	_, word := newOwnedWord(t, db)
+	ctx := context.Background()
+
+	cards := NewCardRepository(db)
+	card := domain.NewReverseCard(word)
+	if err := cards.Create(ctx, card); err != nil {
+		t.Fatalf("create card: %v", err)
+	}
+
+	got, err := cards.GetByWordID(ctx, ownerID, word.ID)
+	if err != nil {
+		t.Fatalf("owner: unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != card.ID {
+		t.Fatalf("owner: got %d cards, want card %d", len(got), card.ID)
+	}
+
+	if got, err := cards.GetByWordID(ctx, strangerID, word.ID); err != nil || len(got) != 0 {
+		t.Errorf("stranger: got %d cards, err %v; want none", len(got), err)
+	}
+
+	card.ReviewCount = 5
+	if err := cards.Update(ctx, strangerID, card); err == nil {
+		t.Error("stranger: update succeeded, want error")
+	}
+
+	got, _ = cards.GetByWordID(ctx, ownerID, word.ID)
+	if got[0].ReviewCount != 0 {
+		t.Errorf("stranger update changed the card: review count %d", got[0].ReviewCount)
+	}
+
+	if err := cards.Update(ctx, ownerID, card); err != nil {
+		t.Fatalf("owner: update: %v", err)
+	}
+	got, _ = cards.GetByWordID(ctx, ownerID, word.ID)
+	if got[0].ReviewCount != 5 {
+		t.Errorf("owner: review count %d, want 5", got[0].ReviewCount)
+	}
+}
+
+func TestReviewLogRepositoryGetByWordID(t *testing.T) {
+	db := newTestDB(t)
+	_, word := newOwnedWord(t, db)
+	ctx := context.Background()
+
+	logs := NewReviewLogRepository(db)
+	entry := &domain.ReviewLog{WordID: word.ID, UserID: ownerID, ReviewedAt: time.Now(), Answer: "дом", IsCorrect: true}
+	if err := logs.Create(ctx, entry); err != nil {
+		t.Fatalf("create review log: %v", err)
+	}
+
+	got, err := logs.GetByWordID(ctx, ownerID, word.ID, 10)
+	if err != nil {
+		t.Fatalf("owner: unexpected error: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != entry.ID {
+		t.Errorf("owner: got %d entries, want entry %d", len(got), entry.ID)
+	}
+
+	if got, err := logs.GetByWordID(ctx, strangerID, word.ID, 10); err != nil || len(got) != 0 {
+		t.Errorf("stranger: got %d entries, err %v; want none", len(got), err)
+	}
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"ivanSaichkin/language-bot/internal/domain"
+)
+
+type deckRepository struct {
+	db *sql.DB
+}
+
+func NewDeckRepository(db *sql.DB) DeckRepository {
+	return &deckRepository{db: db}
+}
+
+func (r *deckRepository) Create(ctx context.Context, deck *domain.Deck) error {
+	query := `INSERT INTO decks (user_id, name, created_at, updated_at) VALUES (?, ?, ?, ?)`
+
+	result, err := r.db.ExecContext(ctx, query, deck.UserID, deck.Name, deck.CreatedAt, deck.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create deck: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	deck.ID = int(id)
+	return nil
+}
+
+// GetByID возвращает колоду пользователя; nil, если её нет или она чужая
+func (r *deckRepository) GetByID(ctx context.Context, userID int64, deckID int) (*domain.Deck, error) {
+	query := `SELECT id, user_id, name, created_at, updated_at FROM decks WHERE id = ? AND user_id = ?`
+
+	var deck domain.Deck
+	err := r.db.QueryRowContext(ctx, query, deckID, userID).Scan(
+		&deck.ID,
+		&deck.UserID,
+		&deck.Name,
+		&deck.CreatedAt,
+		&deck.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deck: %w", err)
+	}
+
+	return &deck, nil
+}
+
+// GetByUserID возвращает колоды пользователя вместе с количеством слов в каждой
+func (r *deckRepository) GetByUserID(ctx context.Context, userID int64) ([]*domain.Deck, error) {
+	query := `
+        SELECT d.id, d.user_id, d.name, d.created_at, d.updated_at, COUNT(w.id)
+        FROM decks d
+        LEFT JOIN words w ON w.deck_id = d.id
+        WHERE d.user_id = ?
+        GROUP BY d.id
+        ORDER BY d.name ASC
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user decks: %w", err)
+	}
+	defer rows.Close()
+
+	var decks []*domain.Deck
+	for rows.Next() {
+		var deck domain.Deck
+		err := rows.Scan(
+			&deck.ID,
+			&deck.UserID,
+			&deck.Name,
+			&deck.CreatedAt,
+			&deck.UpdatedAt,
+			&deck.WordCount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan deck: %w", err)
+		}
+		decks = append(decks, &deck)
+	}
+
+	return decks, nil
+}
+
+func (r *deckRepository) Update(ctx context.Context, userID int64, deck *domain.Deck) error {
+	query := `UPDATE decks SET name = ?, updated_at = ? WHERE id = ? AND user_id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, deck.Name, time.Now(), deck.ID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update deck: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("deck not found")
+	}
+
+	return nil
+}
+
+// Delete удаляет колоду пользователя; её слова остаются у пользователя без колоды
+func (r *deckRepository) Delete(ctx context.Context, userID int64, deckID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `UPDATE words SET deck_id = 0 WHERE deck_id = ? AND user_id = ?`, deckID, userID); err != nil {
+		return fmt.Errorf("failed to detach deck words: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE users SET current_deck_id = 0 WHERE current_deck_id = ? AND id = ?`, deckID, userID); err != nil {
+		return fmt.Errorf("failed to reset current deck: %w", err)
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM decks WHERE id = ? AND user_id = ?`, deckID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete deck: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("deck not found")
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit deck deletion: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"ivanSaichkin/language-bot/internal/domain"
+)
+
+func TestDeckRepositoryOwnership(t *testing.T) {
+	db := newTestDB(t)
+	words, word := newOwnedWord(t, db)
+	ctx := context.Background()
+
+	decks := NewDeckRepository(db)
+	deck := domain.NewDeck(ownerID, "Food")
+	if err := decks.Create(ctx, deck); err != nil {
+		t.Fatalf("create deck: %v", err)
+	}
+	if err := words.MoveToDeck(ctx, ownerID, word.ID, deck.ID); err != nil {
+		t.Fatalf("move word: %v", err)
+	}
+
+	if got, err := decks.GetByID(ctx, ownerID, deck.ID); err != nil || got == nil || got.Name != "Food" {
+		t.Fatalf("owner: got %v, %v", got, err)
+	}
+	if got, err := decks.GetByID(ctx, strangerID, deck.ID); err != nil || got != nil {
+		t.Errorf("stranger: got %v, %v; want nil", got, err)
+	}
+
+	deck.Rename("Stolen")
+	if err := decks.Update(ctx, strangerID, deck); err == nil {
+		t.Error("stranger: rename succeeded, want error")
+	}
+	if err := decks.Delete(ctx, strangerID, deck.ID); err == nil {
+		t.Error("stranger: delete succeeded, want error")
+	}
+
+	got, _ := decks.GetByID(ctx, ownerID, deck.ID)
+	if got == nil || got.Name != "Food" {
+		t.Fatalf("stranger changed the deck: %v", got)
+	}
+	if moved, _ := words.GetByID(ctx, ownerID, word.ID); moved.DeckID != deck.ID {
+		t.Errorf("stranger delete detached the word: deck %d", moved.DeckID)
+	}
+
+	if err := decks.Delete(ctx, ownerID, deck.ID); err != nil {
+		t.Fatalf("owner: delete: %v", err)
+	}
+	if moved, _ := words.GetByID(ctx, ownerID, word.ID); moved.DeckID != 0 {
+		t.Errorf("after delete: word still in deck %d", moved.DeckID)
+	}
+}
@@ -16,13 +16,22 @@ type UserRepository interface {
 
 type WordRepository interface {
 	Create(ctx context.Context, word *domain.Word) error
-	GetByID(ctx context.Context, wordID int) (*domain.Word, error)
+	CreateBatch(ctx context.Context, words []*domain.Word) error
+	// GetByID, Update, Delete и MoveToDeck работают только со словами пользователя userID:
+	// для чужого слова возвращают domain.ErrWordForbidden, для отсутствующего - domain.ErrWordNotFound
+	GetByID(ctx context.Context, userID int64, wordID int) (*domain.Word, error)
 	GetByUserID(ctx context.Context, userID int64) ([]*domain.Word, error)
+	FindByOriginal(ctx context.Context, userID int64, language string, keys []string) ([]*domain.Word, error)
+	GetByLanguage(ctx context.Context, userID int64, language string) ([]*domain.Word, error)
 	GetDueWords(ctx context.Context, userID int64) ([]*domain.Word, error)
-	Update(ctx context.Context, word *domain.Word) error
-	Delete(ctx context.Context, wordID int) error
-	GetRandomTranslations(ctx context.Context, userID int64, exclude string, limit int) ([]string, error)
-	GetWordsForReview(ctx context.Context, userID int64, limit int) ([]*domain.Word, error)
+	Update(ctx context.Context, userID int64, word *domain.Word) error
+	Delete(ctx context.Context, userID int64, wordID int) error
+	MoveToDeck(ctx context.Context, userID int64, wordID int, deckID int) error
+	GetRandomTranslations(ctx context.Context, word *domain.Word, limit int) ([]string, error)
+	GetRandomOriginals(ctx context.Context, word *domain.Word, limit int) ([]string, error)
+	GetWordsForReview(ctx context.Context, userID int64, filter domain.WordFilter, limit int) ([]*domain.Word, error)
+	GetRandomWords(ctx context.Context, userID int64, filter domain.WordFilter, limit int) ([]*domain.Word, error)
+	Search(ctx context.Context, userID int64, query string, limit int) ([]*domain.Word, error)
 }
 
 type StatsRepository interface {
@@ -43,3 +52,29 @@ type SessionRepository interface {
 	CleanupOldSessions(ctx context.Context, olderThan time.Duration) (int, error)
 	GetActiveSessions(ctx context.Context) ([]*domain.ReviewSession, error)
 }
+
+type ReviewLogRepository interface {
+	Create(ctx context.Context, entry *domain.ReviewLog) error
+	Update(ctx context.Context, entry *domain.ReviewLog) error
+	GetByID(ctx context.Context, id int64) (*domain.ReviewLog, error)
+	GetByWordID(ctx context.Context, userID int64, wordID int, limit int) ([]*domain.ReviewLog, error)
+	GetByUserID(ctx context.Context, userID int64, since time.Time) ([]*domain.ReviewLog, error)
+	CountSince(ctx context.Context, userID int64, since time.Time) (int, error)
+}
+
+type CardRepository interface {
+	Create(ctx context.Context, card *domain.Card) error
+	Update(ctx context.Context, userID int64, card *domain.Card) error
+	GetByWordID(ctx context.Context, userID int64, wordID int) ([]*domain.Card, error)
+	GetCardsForReview(ctx context.Context, userID int64, cardType string, filter domain.WordFilter, limit int) ([]*domain.Word, error)
+	CreateMissing(ctx context.Context, userID int64, cardType string) (int, error)
+	GetWordIDsWithCard(ctx context.Context, userID int64, cardType string) (map[int]bool, error)
+}
+
+type DeckRepository interface {
+	Create(ctx context.Context, deck *domain.Deck) error
+	GetByID(ctx context.Context, userID int64, deckID int) (*domain.Deck, error)
+	GetByUserID(ctx context.Context, userID int64) ([]*domain.Deck, error)
+	Update(ctx context.Context, userID int64, deck *domain.Deck) error
+	Delete(ctx context.Context, userID int64, deckID int) error
+}
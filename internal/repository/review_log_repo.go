@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"ivanSaichkin/language-bot/internal/constants"
+	"ivanSaichkin/language-bot/internal/domain"
+)
+
+// Время ответов хранится в UTC: SQLite сравнивает даты как строки,
+// а границы суток считаются в часовом поясе пользователя.
+type reviewLogRepository struct {
+	db *sql.DB
+}
+
+func NewReviewLogRepository(db *sql.DB) ReviewLogRepository {
+	return &reviewLogRepository{db: db}
+}
+
+const reviewLogColumns = `id, word_id, user_id, reviewed_at, answer, is_correct, quality,
+               previous_interval, next_interval, previous_difficulty, new_difficulty, response_time_ms, card_type, mode`
+
+func (r *reviewLogRepository) Create(ctx context.Context, entry *domain.ReviewLog) error {
+	query := `
+        INSERT INTO review_log (word_id, user_id, reviewed_at, answer, is_correct, quality,
+                                previous_interval, next_interval, previous_difficulty, new_difficulty, response_time_ms, card_type, mode)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    `
+
+	result, err := r.db.ExecContext(ctx, query,
+		entry.WordID,
+		entry.UserID,
+		entry.ReviewedAt.UTC(),
+		entry.Answer,
+		entry.IsCorrect,
+		entry.Quality,
+		entry.PreviousInterval,
+		entry.NextInterval,
+		entry.PreviousDifficulty,
+		entry.NewDifficulty,
+		entry.ResponseTime.Milliseconds(),
+		entry.CardType,
+		entry.Mode,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create review log: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert id: %w", err)
+	}
+
+	entry.ID = id
+	return nil
+}
+
+func (r *reviewLogRepository) Update(ctx context.Context, entry *domain.ReviewLog) error {
+	query := `
+        UPDATE review_log
+        SET is_correct = ?, quality = ?, next_interval = ?, new_difficulty = ?
+        WHERE id = ?
+    `
+
+	result, err := r.db.ExecContext(ctx, query,
+		entry.IsCorrect,
+		entry.Quality,
+		entry.NextInterval,
+		entry.NewDifficulty,
+		entry.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update review log: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("review log not found")
+	}
+
+	return nil
+}
+
+func (r *reviewLogRepository) GetByID(ctx context.Context, id int64) (*domain.ReviewLog, error) {
+	query := `SELECT ` + reviewLogColumns + ` FROM review_log WHERE id = ?`
+
+	entry, err := scanReviewLog(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review log: %w", err)
+	}
+
+	return entry, nil
+}
+
+// GetByWordID возвращает последние ответы пользователя по слову
+func (r *reviewLogRepository) GetByWordID(ctx context.Context, userID int64, wordID int, limit int) ([]*domain.ReviewLog, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	query := `
+        SELECT ` + reviewLogColumns + `
+        FROM review_log
+        WHERE word_id = ? AND user_id = ?
+        ORDER BY reviewed_at DESC
+        LIMIT ?
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, wordID, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word review log: %w", err)
+	}
+	defer rows.Close()
+
+	return scanReviewLogs(rows)
+}
+
+func (r *reviewLogRepository) GetByUserID(ctx context.Context, userID int64, since time.Time) ([]*domain.ReviewLog, error) {
+	query := `
+        SELECT ` + reviewLogColumns + `
+        FROM review_log
+        WHERE user_id = ? AND reviewed_at >= ?
+        ORDER BY reviewed_at ASC
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, userID, since.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user review log: %w", err)
+	}
+	defer rows.Close()
+
+	return scanReviewLogs(rows)
+}
+
+// CountSince считает повторения без ответов в играх: они не засчитываются в дневную цель
+func (r *reviewLogRepository) CountSince(ctx context.Context, userID int64, since time.Time) (int, error) {
+	query := `SELECT COUNT(*) FROM review_log WHERE user_id = ? AND reviewed_at >= ? AND mode NOT IN (?, ?)`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, userID, since.UTC(), constants.GameModeSprint, constants.GameModeMatch).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count reviews: %w", err)
+	}
+
+	return count, nil
+}
+
+func scanReviewLog(row rowScanner) (*domain.ReviewLog, error) {
+	var entry domain.ReviewLog
+	var responseTimeMs int64
+
+	err := row.Scan(
+		&entry.ID,
+		&entry.WordID,
+		&entry.UserID,
+		&entry.ReviewedAt,
+		&entry.Answer,
+		&entry.IsCorrect,
+		&entry.Quality,
+		&entry.PreviousInterval,
+		&entry.NextInterval,
+		&entry.PreviousDifficulty,
+		&entry.NewDifficulty,
+		&responseTimeMs,
+		&entry.CardType,
+		&entry.Mode,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.ResponseTime = time.Duration(responseTimeMs) * time.Millisecond
+	return &entry, nil
+}
+
+func scanReviewLogs(rows *sql.Rows) ([]*domain.ReviewLog, error) {
+	var entries []*domain.ReviewLog
+	for rows.Next() {
+		entry, err := scanReviewLog(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan review log: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
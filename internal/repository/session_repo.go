@@ -25,9 +25,15 @@ func (r *sessionRepository) Create(ctx context.Context, session *domain.ReviewSe
 		return fmt.Errorf("failed to marshal words: %w", err)
 	}
 
+	choicesJSON, err := marshalChoices(session.Choices)
+	if err != nil {
+		return err
+	}
+
 	query := `
-        INSERT INTO review_sessions (id, user_id, correct_answers, total_questions, start_time, end_time, is_completed, words_data)
-        VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+        INSERT INTO review_sessions (id, user_id, correct_answers, total_questions, start_time, end_time, is_completed, words_data,
+                                     question_shown_at, mode, choices_data)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
     `
 
 	_, err = r.db.ExecContext(ctx, query,
@@ -39,6 +45,9 @@ func (r *sessionRepository) Create(ctx context.Context, session *domain.ReviewSe
 		session.EndTime,
 		session.IsCompleted,
 		wordsJSON,
+		nullTime(session.QuestionShownAt),
+		session.Mode,
+		choicesJSON,
 	)
 
 	if err != nil {
@@ -51,13 +60,16 @@ func (r *sessionRepository) Create(ctx context.Context, session *domain.ReviewSe
 
 func (r *sessionRepository) GetByID(ctx context.Context, sessionID string) (*domain.ReviewSession, error) {
 	query := `
-        SELECT id, user_id, correct_answers, total_questions, start_time, end_time, is_completed, words_data
+        SELECT id, user_id, correct_answers, total_questions, start_time, end_time, is_completed, words_data,
+               question_shown_at, mode, choices_data
         FROM review_sessions WHERE id = ?
     `
 
 	var session domain.ReviewSession
 	var wordsJSON string
 	var endTime sql.NullTime
+	var questionShownAt sql.NullTime
+	var choicesJSON string
 
 	err := r.db.QueryRowContext(ctx, query, sessionID).Scan(
 		&session.ID,
@@ -68,6 +80,9 @@ func (r *sessionRepository) GetByID(ctx context.Context, sessionID string) (*dom
 		&endTime,
 		&session.IsCompleted,
 		&wordsJSON,
+		&questionShownAt,
+		&session.Mode,
+		&choicesJSON,
 	)
 
 	if err == sql.ErrNoRows {
@@ -92,12 +107,18 @@ func (r *sessionRepository) GetByID(ctx context.Context, sessionID string) (*dom
 		session.EndTime = endTime.Time
 	}
 
+	if questionShownAt.Valid {
+		session.QuestionShownAt = questionShownAt.Time
+	}
+	session.Choices = unmarshalChoices(choicesJSON)
+
 	return &session, nil
 }
 
 func (r *sessionRepository) GetByUserID(ctx context.Context, userID int64) ([]*domain.ReviewSession, error) {
 	query := `
-        SELECT id, user_id, correct_answers, total_questions, start_time, end_time, is_completed, words_data
+        SELECT id, user_id, correct_answers, total_questions, start_time, end_time, is_completed, words_data,
+               question_shown_at, mode, choices_data
         FROM review_sessions WHERE user_id = ?
         ORDER BY start_time DESC
     `
@@ -113,6 +134,8 @@ func (r *sessionRepository) GetByUserID(ctx context.Context, userID int64) ([]*d
 		var session domain.ReviewSession
 		var wordsJSON string
 		var endTime sql.NullTime
+		var questionShownAt sql.NullTime
+		var choicesJSON string
 
 		err := rows.Scan(
 			&session.ID,
@@ -123,6 +146,9 @@ func (r *sessionRepository) GetByUserID(ctx context.Context, userID int64) ([]*d
 			&endTime,
 			&session.IsCompleted,
 			&wordsJSON,
+			&questionShownAt,
+			&session.Mode,
+			&choicesJSON,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan session: %w", err)
@@ -144,6 +170,11 @@ func (r *sessionRepository) GetByUserID(ctx context.Context, userID int64) ([]*d
 			session.EndTime = endTime.Time
 		}
 
+		if questionShownAt.Valid {
+			session.QuestionShownAt = questionShownAt.Time
+		}
+		session.Choices = unmarshalChoices(choicesJSON)
+
 		sessions = append(sessions, &session)
 	}
 
@@ -156,9 +187,15 @@ func (r *sessionRepository) Update(ctx context.Context, session *domain.ReviewSe
 		return fmt.Errorf("failed to marshal words: %w", err)
 	}
 
+	choicesJSON, err := marshalChoices(session.Choices)
+	if err != nil {
+		return err
+	}
+
 	query := `
         UPDATE review_sessions
-        SET correct_answers = ?, total_questions = ?, end_time = ?, is_completed = ?, words_data = ?
+        SET correct_answers = ?, total_questions = ?, end_time = ?, is_completed = ?, words_data = ?,
+            question_shown_at = ?, mode = ?, choices_data = ?
         WHERE id = ?
     `
 
@@ -168,6 +205,9 @@ func (r *sessionRepository) Update(ctx context.Context, session *domain.ReviewSe
 		session.EndTime,
 		session.IsCompleted,
 		wordsJSON,
+		nullTime(session.QuestionShownAt),
+		session.Mode,
+		choicesJSON,
 		session.ID,
 	)
 
@@ -249,7 +289,8 @@ func (r *sessionRepository) CleanupOldSessions(ctx context.Context, olderThan ti
 
 func (r *sessionRepository) GetActiveSessions(ctx context.Context) ([]*domain.ReviewSession, error) {
 	query := `
-        SELECT id, user_id, correct_answers, total_questions, start_time, end_time, is_completed, words_data
+        SELECT id, user_id, correct_answers, total_questions, start_time, end_time, is_completed, words_data,
+               question_shown_at, mode, choices_data
         FROM review_sessions WHERE is_completed = 0
         ORDER BY start_time ASC
     `
@@ -265,6 +306,8 @@ func (r *sessionRepository) GetActiveSessions(ctx context.Context) ([]*domain.Re
 		var session domain.ReviewSession
 		var wordsJSON string
 		var endTime sql.NullTime
+		var questionShownAt sql.NullTime
+		var choicesJSON string
 
 		err := rows.Scan(
 			&session.ID,
@@ -275,6 +318,9 @@ func (r *sessionRepository) GetActiveSessions(ctx context.Context) ([]*domain.Re
 			&endTime,
 			&session.IsCompleted,
 			&wordsJSON,
+			&questionShownAt,
+			&session.Mode,
+			&choicesJSON,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan session: %w", err)
@@ -296,8 +342,40 @@ func (r *sessionRepository) GetActiveSessions(ctx context.Context) ([]*domain.Re
 			session.EndTime = endTime.Time
 		}
 
+		if questionShownAt.Valid {
+			session.QuestionShownAt = questionShownAt.Time
+		}
+		session.Choices = unmarshalChoices(choicesJSON)
+
 		sessions = append(sessions, &session)
 	}
 
 	return sessions, nil
 }
+
+func marshalChoices(choices []string) (string, error) {
+	if len(choices) == 0 {
+		return "", nil
+	}
+
+	data, err := json.Marshal(choices)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal choices: %w", err)
+	}
+
+	return string(data), nil
+}
+
+func unmarshalChoices(data string) []string {
+	if data == "" {
+		return nil
+	}
+
+	var choices []string
+	if err := json.Unmarshal([]byte(data), &choices); err != nil {
+		log.Printf("⚠️ Failed to unmarshal session choices: %v", err)
+		return nil
+	}
+
+	return choices
+}
@@ -103,6 +103,64 @@ func initSQLiteSchema(db *sql.DB) error {
             words_data TEXT NOT NULL,
             FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
         )`,
+
+		`CREATE TABLE IF NOT EXISTS word_translations (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            word_id INTEGER NOT NULL,
+            position INTEGER DEFAULT 0,
+            translation TEXT NOT NULL,
+            normalized TEXT NOT NULL,
+            FOREIGN KEY (word_id) REFERENCES words (id) ON DELETE CASCADE
+        )`,
+
+		// Дополнительные карточки слова (например, обратная) со своим состоянием повторения
+		`CREATE TABLE IF NOT EXISTS word_cards (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            word_id INTEGER NOT NULL,
+            user_id INTEGER NOT NULL,
+            card_type TEXT NOT NULL,
+            difficulty REAL DEFAULT 2.5,
+            next_review DATETIME DEFAULT CURRENT_TIMESTAMP,
+            review_count INTEGER DEFAULT 0,
+            correct_answers INTEGER DEFAULT 0,
+            interval_days REAL DEFAULT 0,
+            last_reviewed_at DATETIME,
+            stability REAL DEFAULT 0,
+            fsrs_difficulty REAL DEFAULT 0,
+            retrievability REAL DEFAULT 0,
+            created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+            updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+            UNIQUE (word_id, card_type),
+            FOREIGN KEY (word_id) REFERENCES words (id) ON DELETE CASCADE
+        )`,
+
+		// Колоды для группировки слов. deck_id у слов без внешнего ключа: 0 означает «без колоды»
+		`CREATE TABLE IF NOT EXISTS decks (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            user_id INTEGER NOT NULL,
+            name TEXT NOT NULL,
+            created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+            updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+            UNIQUE (user_id, name),
+            FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+        )`,
+
+		// word_id без внешнего ключа: история ответов сохраняется и после удаления слова
+		`CREATE TABLE IF NOT EXISTS review_log (
+            id INTEGER PRIMARY KEY AUTOINCREMENT,
+            word_id INTEGER NOT NULL,
+            user_id INTEGER NOT NULL,
+            reviewed_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+            answer TEXT DEFAULT '',
+            is_correct BOOLEAN DEFAULT FALSE,
+            quality INTEGER DEFAULT 0,
+            previous_interval REAL DEFAULT 0,
+            next_interval REAL DEFAULT 0,
+            previous_difficulty REAL DEFAULT 0,
+            new_difficulty REAL DEFAULT 0,
+            response_time_ms INTEGER DEFAULT 0,
+            FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+        )`,
 	}
 
 	for i, tableSQL := range tables {
@@ -111,6 +169,41 @@ func initSQLiteSchema(db *sql.DB) error {
 		}
 	}
 
+	// Колонки, появившиеся после первой версии схемы
+	columns := []struct {
+		table      string
+		column     string
+		definition string
+	}{
+		{"words", "interval_days", "REAL DEFAULT 0"},
+		{"words", "last_reviewed_at", "DATETIME"},
+		{"words", "stability", "REAL DEFAULT 0"},
+		{"words", "fsrs_difficulty", "REAL DEFAULT 0"},
+		{"words", "retrievability", "REAL DEFAULT 0"},
+		{"users", "scheduler", "TEXT DEFAULT 'sm2'"},
+		{"users", "desired_retention", "REAL DEFAULT 0.9"},
+		{"users", "timezone", "TEXT DEFAULT ''"},
+		{"users", "answer_strictness", "TEXT DEFAULT 'normal'"},
+		{"users", "card_directions", "TEXT DEFAULT 'forward'"},
+		{"review_log", "card_type", "TEXT DEFAULT 'forward'"},
+		{"review_log", "mode", "TEXT DEFAULT 'typing'"},
+		{"review_sessions", "mode", "TEXT DEFAULT 'typing'"},
+		{"review_sessions", "choices_data", "TEXT DEFAULT ''"},
+		{"review_sessions", "question_shown_at", "DATETIME"},
+		{"user_stats", "sprint_best", "INTEGER DEFAULT 0"},
+		{"words", "deck_id", "INTEGER DEFAULT 0"},
+		{"users", "current_deck_id", "INTEGER DEFAULT 0"},
+		{"users", "study_language", "TEXT DEFAULT 'en'"},
+		{"words", "normalized_original", "TEXT DEFAULT ''"},
+		{"words", "normalized_example", "TEXT DEFAULT ''"},
+	}
+
+	for _, c := range columns {
+		if err := addColumnIfNotExists(db, c.table, c.column, c.definition); err != nil {
+			return fmt.Errorf("failed to add column %s.%s: %w", c.table, c.column, err)
+		}
+	}
+
 	indexes := []string{
 		"CREATE INDEX IF NOT EXISTS idx_words_user_id ON words(user_id)",
 		"CREATE INDEX IF NOT EXISTS idx_words_next_review ON words(next_review)",
@@ -118,6 +211,13 @@ func initSQLiteSchema(db *sql.DB) error {
 		"CREATE INDEX IF NOT EXISTS idx_review_sessions_user_id ON review_sessions(user_id)",
 		"CREATE INDEX IF NOT EXISTS idx_review_sessions_completed ON review_sessions(is_completed)",
 		"CREATE INDEX IF NOT EXISTS idx_review_sessions_time ON review_sessions(start_time)",
+		"CREATE INDEX IF NOT EXISTS idx_review_log_user_time ON review_log(user_id, reviewed_at)",
+		"CREATE INDEX IF NOT EXISTS idx_review_log_word_id ON review_log(word_id)",
+		"CREATE INDEX IF NOT EXISTS idx_word_translations_word_id ON word_translations(word_id)",
+		"CREATE INDEX IF NOT EXISTS idx_word_translations_normalized ON word_translations(normalized)",
+		"CREATE INDEX IF NOT EXISTS idx_word_cards_user_next_review ON word_cards(user_id, next_review)",
+		"CREATE INDEX IF NOT EXISTS idx_words_user_deck ON words(user_id, deck_id)",
+		"CREATE INDEX IF NOT EXISTS idx_words_user_normalized ON words(user_id, language, normalized_original)",
 	}
 
 	for _, indexSQL := range indexes {
@@ -126,6 +226,46 @@ func initSQLiteSchema(db *sql.DB) error {
 		}
 	}
 
+	if err := backfillSearchKeys(db); err != nil {
+		return fmt.Errorf("failed to backfill search keys: %w", err)
+	}
+
+	if err := initWordSearchIndex(db); err != nil {
+		log.Printf("⚠️ FTS5 word index is unavailable, /find falls back to LIKE scanning. "+
+			"Build the bot with -tags sqlite_fts5 (make build) to enable it: %v", err)
+	}
+
 	log.Println("✅ SQLite schema initialized successfully")
 	return nil
 }
+
+func addColumnIfNotExists(db *sql.DB, table, column, definition string) error {
+	rows, err := db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid        int
+			name       string
+			columnType string
+			notNull    int
+			defaultVal sql.NullString
+			primaryKey int
+		)
+		if err := rows.Scan(&cid, &name, &columnType, &notNull, &defaultVal, &primaryKey); err != nil {
+			return err
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	_, err = db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition))
+	return err
+}
@@ -20,8 +20,8 @@ func NewStatsRepository(db *sql.DB) StatsRepository {
 func (r *statsRepository) Create(ctx context.Context, stats *domain.UserStats) error {
 	query := `
         INSERT INTO user_stats (user_id, total_words, learned_words, total_reviews, total_correct,
-                               streak_days, max_streak_days, total_time, last_review_date, created_at, updated_at)
-        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+                               streak_days, max_streak_days, sprint_best, total_time, last_review_date, created_at, updated_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
     `
 
 	_, err := r.db.ExecContext(ctx, query,
@@ -32,6 +32,7 @@ func (r *statsRepository) Create(ctx context.Context, stats *domain.UserStats) e
 		stats.TotalCorrect,
 		stats.StreakDays,
 		stats.MaxStreakDays,
+		stats.SprintBest,
 		stats.TotalTime,
 		stats.LastReviewDate,
 		stats.CreatedAt,
@@ -44,7 +45,7 @@ func (r *statsRepository) Create(ctx context.Context, stats *domain.UserStats) e
 func (r *statsRepository) GetByUserID(ctx context.Context, userID int64) (*domain.UserStats, error) {
 	query := `
         SELECT user_id, total_words, learned_words, total_reviews, total_correct,
-               streak_days, max_streak_days, total_time, last_review_date, created_at, updated_at
+               streak_days, max_streak_days, sprint_best, total_time, last_review_date, created_at, updated_at
         FROM user_stats WHERE user_id = ?
     `
 
@@ -57,6 +58,7 @@ func (r *statsRepository) GetByUserID(ctx context.Context, userID int64) (*domai
 		&stats.TotalCorrect,
 		&stats.StreakDays,
 		&stats.MaxStreakDays,
+		&stats.SprintBest,
 		&stats.TotalTime,
 		&stats.LastReviewDate,
 		&stats.CreatedAt,
@@ -77,7 +79,7 @@ func (r *statsRepository) Update(ctx context.Context, stats *domain.UserStats) e
 	query := `
         UPDATE user_stats
         SET total_words = ?, learned_words = ?, total_reviews = ?, total_correct = ?,
-            streak_days = ?, max_streak_days = ?, total_time = ?, last_review_date = ?, updated_at = ?
+            streak_days = ?, max_streak_days = ?, sprint_best = ?, total_time = ?, last_review_date = ?, updated_at = ?
         WHERE user_id = ?
     `
 
@@ -88,6 +90,7 @@ func (r *statsRepository) Update(ctx context.Context, stats *domain.UserStats) e
 		stats.TotalCorrect,
 		stats.StreakDays,
 		stats.MaxStreakDays,
+		stats.SprintBest,
 		stats.TotalTime,
 		stats.LastReviewDate,
 		time.Now(),
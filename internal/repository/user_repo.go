@@ -20,8 +20,9 @@ func NewUserRepository(db *sql.DB) UserRepository {
 
 func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 	query := `
-        INSERT INTO users (id, username, first_name, last_name, language_code, state, daily_goal, created_at, updated_at)
-        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+        INSERT INTO users (id, username, first_name, last_name, language_code, state, daily_goal,
+                           scheduler, desired_retention, timezone, answer_strictness, card_directions, current_deck_id, study_language, created_at, updated_at)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
     `
 
 	_, err := r.db.ExecContext(ctx, query,
@@ -32,6 +33,13 @@ func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 		user.LanguageCode,
 		string(user.State),
 		user.DailyGoal,
+		user.Scheduler,
+		user.DesiredRetention,
+		user.Timezone,
+		user.AnswerStrictness,
+		user.CardDirections,
+		user.CurrentDeckID,
+		user.StudyLanguage,
 		user.CreatedAt,
 		user.UpdatedAt,
 	)
@@ -47,7 +55,8 @@ func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 
 func (r *userRepository) GetByID(ctx context.Context, userID int64) (*domain.User, error) {
 	query := `
-        SELECT id, username, first_name, last_name, language_code, state, daily_goal, created_at, updated_at
+        SELECT id, username, first_name, last_name, language_code, state, daily_goal,
+               scheduler, desired_retention, timezone, answer_strictness, card_directions, current_deck_id, study_language, created_at, updated_at
         FROM users WHERE id = ?
     `
 
@@ -62,6 +71,13 @@ func (r *userRepository) GetByID(ctx context.Context, userID int64) (*domain.Use
 		&user.LanguageCode,
 		&state,
 		&user.DailyGoal,
+		&user.Scheduler,
+		&user.DesiredRetention,
+		&user.Timezone,
+		&user.AnswerStrictness,
+		&user.CardDirections,
+		&user.CurrentDeckID,
+		&user.StudyLanguage,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
@@ -81,7 +97,7 @@ func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
 	query := `
         UPDATE users
         SET username = ?, first_name = ?, last_name = ?, language_code = ?,
-            state = ?, daily_goal = ?, updated_at = ?
+            state = ?, daily_goal = ?, scheduler = ?, desired_retention = ?, timezone = ?, answer_strictness = ?, card_directions = ?, current_deck_id = ?, study_language = ?, updated_at = ?
         WHERE id = ?
     `
 
@@ -92,6 +108,13 @@ func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
 		user.LanguageCode,
 		string(user.State),
 		user.DailyGoal,
+		user.Scheduler,
+		user.DesiredRetention,
+		user.Timezone,
+		user.AnswerStrictness,
+		user.CardDirections,
+		user.CurrentDeckID,
+		user.StudyLanguage,
 		time.Now(),
 		user.ID,
 	)
@@ -134,7 +157,8 @@ func (r *userRepository) UpdateState(ctx context.Context, userID int64, state st
 
 func (r *userRepository) GetAll(ctx context.Context) ([]*domain.User, error) {
 	query := `
-        SELECT id, username, first_name, last_name, language_code, state, daily_goal, created_at
+        SELECT id, username, first_name, last_name, language_code, state, daily_goal,
+               scheduler, desired_retention, timezone, answer_strictness, card_directions, current_deck_id, study_language, created_at
         FROM users
     `
 
@@ -157,6 +181,13 @@ func (r *userRepository) GetAll(ctx context.Context) ([]*domain.User, error) {
 			&user.LanguageCode,
 			&state,
 			&user.DailyGoal,
+			&user.Scheduler,
+			&user.DesiredRetention,
+			&user.Timezone,
+			&user.AnswerStrictness,
+			&user.CardDirections,
+			&user.CurrentDeckID,
+			&user.StudyLanguage,
 			&user.CreatedAt,
 		)
 		if err != nil {
@@ -4,27 +4,80 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"ivanSaichkin/language-bot/internal/domain"
 )
 
+const wordColumns = `id, user_id, original, translation, language, part_of_speech, example,
+               difficulty, next_review, review_count, correct_answers, created_at, updated_at,
+               interval_days, last_reviewed_at, stability, fsrs_difficulty, retrievability, deck_id`
+
 type wordRepository struct {
-	db *sql.DB
+	db          *sql.DB
+	searchIndex bool // есть полнотекстовый индекс words_fts
 }
 
 func NewWordRepository(db *sql.DB) WordRepository {
-	return &wordRepository{db: db}
+	return &wordRepository{db: db, searchIndex: hasWordSearchIndex(db)}
 }
 
 func (r *wordRepository) Create(ctx context.Context, word *domain.Word) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := r.insertWord(ctx, tx, word); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit word: %w", err)
+	}
+
+	// Обновляем статистику пользователя
+	return r.updateUserWordStats(ctx, word.UserID)
+}
+
+// CreateBatch добавляет слова одного пользователя в одной транзакции:
+// при ошибке не сохраняется ни одно слово
+func (r *wordRepository) CreateBatch(ctx context.Context, words []*domain.Word) error {
+	if len(words) == 0 {
+		return nil
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, word := range words {
+		if err := r.insertWord(ctx, tx, word); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit words: %w", err)
+	}
+
+	return r.updateUserWordStats(ctx, words[0].UserID)
+}
+
+func (r *wordRepository) insertWord(ctx context.Context, tx *sql.Tx, word *domain.Word) error {
 	query := `
         INSERT INTO words (user_id, original, translation, language, part_of_speech, example,
-                          difficulty, next_review, review_count, correct_answers, created_at, updated_at)
-        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+                          difficulty, next_review, review_count, correct_answers, created_at, updated_at,
+                          interval_days, last_reviewed_at, stability, fsrs_difficulty, retrievability, deck_id,
+                          normalized_original, normalized_example)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
     `
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := tx.ExecContext(ctx, query,
 		word.UserID,
 		word.Original,
 		word.Translation,
@@ -37,6 +90,14 @@ func (r *wordRepository) Create(ctx context.Context, word *domain.Word) error {
 		word.CorrectAnswers,
 		word.CreatedAt,
 		word.UpdatedAt,
+		word.IntervalDays,
+		nullTime(word.LastReviewedAt),
+		word.Stability,
+		word.FSRSDifficulty,
+		word.Retrievability,
+		word.DeckID,
+		domain.SearchKey(word.Original),
+		domain.SearchKey(word.Example),
 	)
 
 	if err != nil {
@@ -51,48 +112,33 @@ func (r *wordRepository) Create(ctx context.Context, word *domain.Word) error {
 
 	word.ID = int(id)
 
-	// Обновляем статистику пользователя
-	return r.updateUserWordStats(ctx, word.UserID)
+	return r.saveTranslations(ctx, tx, word)
 }
 
-func (r *wordRepository) GetByID(ctx context.Context, wordID int) (*domain.Word, error) {
+func (r *wordRepository) GetByID(ctx context.Context, userID int64, wordID int) (*domain.Word, error) {
 	query := `
-        SELECT id, user_id, original, translation, language, part_of_speech, example,
-               difficulty, next_review, review_count, correct_answers, created_at, updated_at
-        FROM words WHERE id = ?
+        SELECT ` + wordColumns + `
+        FROM words WHERE id = ? AND user_id = ?
     `
 
-	var word domain.Word
-	err := r.db.QueryRowContext(ctx, query, wordID).Scan(
-		&word.ID,
-		&word.UserID,
-		&word.Original,
-		&word.Translation,
-		&word.Language,
-		&word.PartOfSpeech,
-		&word.Example,
-		&word.Difficulty,
-		&word.NextReview,
-		&word.ReviewCount,
-		&word.CorrectAnswers,
-		&word.CreatedAt,
-		&word.UpdatedAt,
-	)
-
+	word, err := scanWord(r.db.QueryRowContext(ctx, query, wordID, userID))
 	if err == sql.ErrNoRows {
-		return nil, nil
+		return nil, r.accessError(ctx, userID, wordID)
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get word: %w", err)
 	}
 
-	return &word, nil
+	if err := loadTranslations(ctx, r.db, []*domain.Word{word}); err != nil {
+		return nil, err
+	}
+
+	return word, nil
 }
 
 func (r *wordRepository) GetByUserID(ctx context.Context, userID int64) ([]*domain.Word, error) {
 	query := `
-        SELECT id, user_id, original, translation, language, part_of_speech, example,
-               difficulty, next_review, review_count, correct_answers, created_at, updated_at
+        SELECT ` + wordColumns + `
         FROM words WHERE user_id = ?
         ORDER BY next_review ASC
     `
@@ -103,37 +149,59 @@ func (r *wordRepository) GetByUserID(ctx context.Context, userID int64) ([]*doma
 	}
 	defer rows.Close()
 
-	var words []*domain.Word
-	for rows.Next() {
-		var word domain.Word
-		err := rows.Scan(
-			&word.ID,
-			&word.UserID,
-			&word.Original,
-			&word.Translation,
-			&word.Language,
-			&word.PartOfSpeech,
-			&word.Example,
-			&word.Difficulty,
-			&word.NextReview,
-			&word.ReviewCount,
-			&word.CorrectAnswers,
-			&word.CreatedAt,
-			&word.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan word: %w", err)
-		}
-		words = append(words, &word)
+	return r.scanWordsWithTranslations(ctx, rows)
+}
+
+// FindByOriginal возвращает слова языка language, ключ поиска оригинала которых
+// совпадает с одним из keys (domain.SearchKey). Запрос идёт по индексу idx_words_user_normalized.
+func (r *wordRepository) FindByOriginal(ctx context.Context, userID int64, language string, keys []string) ([]*domain.Word, error) {
+	if len(keys) == 0 {
+		return nil, nil
 	}
 
-	return words, nil
+	placeholders := make([]string, 0, len(keys))
+	args := []any{userID, language}
+	for _, key := range keys {
+		placeholders = append(placeholders, "?")
+		args = append(args, key)
+	}
+
+	query := `
+        SELECT ` + wordColumns + `
+        FROM words
+        WHERE user_id = ? AND language = ? AND normalized_original IN (` + strings.Join(placeholders, ", ") + `)
+        ORDER BY id
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find words by original: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanWordsWithTranslations(ctx, rows)
+}
+
+// GetByLanguage возвращает все слова пользователя на языке language
+func (r *wordRepository) GetByLanguage(ctx context.Context, userID int64, language string) ([]*domain.Word, error) {
+	query := `
+        SELECT ` + wordColumns + `
+        FROM words WHERE user_id = ? AND language = ?
+        ORDER BY id
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, userID, language)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get words by language: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanWordsWithTranslations(ctx, rows)
 }
 
 func (r *wordRepository) GetDueWords(ctx context.Context, userID int64) ([]*domain.Word, error) {
 	query := `
-        SELECT id, user_id, original, translation, language, part_of_speech, example,
-               difficulty, next_review, review_count, correct_answers, created_at, updated_at
+        SELECT ` + wordColumns + `
         FROM words
         WHERE user_id = ? AND next_review <= ?
         ORDER BY next_review ASC
@@ -146,89 +214,75 @@ func (r *wordRepository) GetDueWords(ctx context.Context, userID int64) ([]*doma
 	}
 	defer rows.Close()
 
-	var words []*domain.Word
-	for rows.Next() {
-		var word domain.Word
-		err := rows.Scan(
-			&word.ID,
-			&word.UserID,
-			&word.Original,
-			&word.Translation,
-			&word.Language,
-			&word.PartOfSpeech,
-			&word.Example,
-			&word.Difficulty,
-			&word.NextReview,
-			&word.ReviewCount,
-			&word.CorrectAnswers,
-			&word.CreatedAt,
-			&word.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan word: %w", err)
-		}
-		words = append(words, &word)
-	}
-
-	return words, nil
+	return r.scanWordsWithTranslations(ctx, rows)
 }
 
-func (r *wordRepository) GetWordsForReview(ctx context.Context, userID int64, limit int) ([]*domain.Word, error) {
+// GetWordsForReview возвращает слова, которые пора повторить, с учётом колоды и языка из filter
+func (r *wordRepository) GetWordsForReview(ctx context.Context, userID int64, filter domain.WordFilter, limit int) ([]*domain.Word, error) {
 	if limit <= 0 || limit > 50 {
 		limit = 10
 	}
 
 	query := `
-        SELECT id, user_id, original, translation, language, part_of_speech, example,
-               difficulty, next_review, review_count, correct_answers, created_at, updated_at
+        SELECT ` + wordColumns + `
         FROM words
         WHERE user_id = ? AND next_review <= ?
+          AND (? = 0 OR deck_id = ?) AND (? = '' OR language = ?)
         ORDER BY next_review ASC
         LIMIT ?
     `
 
-	rows, err := r.db.QueryContext(ctx, query, userID, time.Now(), limit)
+	rows, err := r.db.QueryContext(ctx, query, userID, time.Now(),
+		filter.DeckID, filter.DeckID, filter.Language, filter.Language, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get words for review: %w", err)
 	}
 	defer rows.Close()
 
-	var words []*domain.Word
-	for rows.Next() {
-		var word domain.Word
-		err := rows.Scan(
-			&word.ID,
-			&word.UserID,
-			&word.Original,
-			&word.Translation,
-			&word.Language,
-			&word.PartOfSpeech,
-			&word.Example,
-			&word.Difficulty,
-			&word.NextReview,
-			&word.ReviewCount,
-			&word.CorrectAnswers,
-			&word.CreatedAt,
-			&word.UpdatedAt,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan word: %w", err)
-		}
-		words = append(words, &word)
+	return r.scanWordsWithTranslations(ctx, rows)
+}
+
+// GetRandomWords возвращает случайные слова пользователя независимо от срока повторения.
+// filter ограничивает колоду и язык, нулевой filter - все слова.
+func (r *wordRepository) GetRandomWords(ctx context.Context, userID int64, filter domain.WordFilter, limit int) ([]*domain.Word, error) {
+	if limit <= 0 || limit > 50 {
+		limit = 10
 	}
 
-	return words, nil
+	query := `
+        SELECT ` + wordColumns + `
+        FROM words
+        WHERE user_id = ? AND (? = 0 OR deck_id = ?) AND (? = '' OR language = ?)
+        ORDER BY RANDOM()
+        LIMIT ?
+    `
+
+	rows, err := r.db.QueryContext(ctx, query, userID, filter.DeckID, filter.DeckID, filter.Language, filter.Language, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get random words: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanWordsWithTranslations(ctx, rows)
 }
 
-func (r *wordRepository) Update(ctx context.Context, word *domain.Word) error {
+func (r *wordRepository) Update(ctx context.Context, userID int64, word *domain.Word) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	query := `
         UPDATE words
         SET original = ?, translation = ?, language = ?, part_of_speech = ?, example = ?,
-            difficulty = ?, next_review = ?, review_count = ?, correct_answers = ?, updated_at = ?
-        WHERE id = ?
+            difficulty = ?, next_review = ?, review_count = ?, correct_answers = ?, updated_at = ?,
+            interval_days = ?, last_reviewed_at = ?, stability = ?, fsrs_difficulty = ?, retrievability = ?, deck_id = ?,
+            normalized_original = ?, normalized_example = ?
+        WHERE id = ? AND user_id = ?
     `
 
-	result, err := r.db.ExecContext(ctx, query,
+	result, err := tx.ExecContext(ctx, query,
 		word.Original,
 		word.Translation,
 		word.Language,
@@ -239,7 +293,16 @@ func (r *wordRepository) Update(ctx context.Context, word *domain.Word) error {
 		word.ReviewCount,
 		word.CorrectAnswers,
 		time.Now(),
+		word.IntervalDays,
+		nullTime(word.LastReviewedAt),
+		word.Stability,
+		word.FSRSDifficulty,
+		word.Retrievability,
+		word.DeckID,
+		domain.SearchKey(word.Original),
+		domain.SearchKey(word.Example),
 		word.ID,
+		userID,
 	)
 
 	if err != nil {
@@ -252,23 +315,62 @@ func (r *wordRepository) Update(ctx context.Context, word *domain.Word) error {
 	}
 
 	if rows == 0 {
-		return fmt.Errorf("word not found")
+		tx.Rollback()
+		return r.accessError(ctx, userID, word.ID)
 	}
 
-	return r.updateUserWordStats(ctx, word.UserID)
+	// Слово из сессии повторения может прийти без списка переводов - не затираем его
+	if len(word.Translations) > 0 {
+		if err := r.saveTranslations(ctx, tx, word); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit word: %w", err)
+	}
+
+	return r.updateUserWordStats(ctx, userID)
 }
 
-func (r *wordRepository) Delete(ctx context.Context, wordID int) error {
-	word, err := r.GetByID(ctx, wordID)
+// MoveToDeck переносит слово в колоду; deckID = 0 - убрать слово из колоды
+func (r *wordRepository) MoveToDeck(ctx context.Context, userID int64, wordID int, deckID int) error {
+	query := `UPDATE words SET deck_id = ?, updated_at = ? WHERE id = ? AND user_id = ?`
+
+	result, err := r.db.ExecContext(ctx, query, deckID, time.Now(), wordID, userID)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to move word: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return r.accessError(ctx, userID, wordID)
+	}
+
+	return nil
+}
+
+func (r *wordRepository) Delete(ctx context.Context, userID int64, wordID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	if word == nil {
-		return fmt.Errorf("word not found")
+	defer tx.Rollback()
+
+	// Переводы удаляются явно: каскад сработает, только если включены внешние ключи
+	_, err = tx.ExecContext(ctx, `
+        DELETE FROM word_translations
+        WHERE word_id IN (SELECT id FROM words WHERE id = ? AND user_id = ?)
+    `, wordID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete word translations: %w", err)
 	}
 
-	query := `DELETE FROM words WHERE id = ?`
-	result, err := r.db.ExecContext(ctx, query, wordID)
+	result, err := tx.ExecContext(ctx, `DELETE FROM words WHERE id = ? AND user_id = ?`, wordID, userID)
 	if err != nil {
 		return fmt.Errorf("failed to delete word: %w", err)
 	}
@@ -279,36 +381,67 @@ func (r *wordRepository) Delete(ctx context.Context, wordID int) error {
 	}
 
 	if rows == 0 {
-		return fmt.Errorf("word not found")
+		tx.Rollback()
+		return r.accessError(ctx, userID, wordID)
 	}
 
-	return r.updateUserWordStats(ctx, word.UserID)
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit word deletion: %w", err)
+	}
+
+	return r.updateUserWordStats(ctx, userID)
 }
 
-func (r *wordRepository) GetRandomTranslations(ctx context.Context, userID int64, exclude string, limit int) ([]string, error) {
+// accessError объясняет, почему слово не нашлось среди слов пользователя:
+// его нет совсем или оно принадлежит другому пользователю
+func (r *wordRepository) accessError(ctx context.Context, userID int64, wordID int) error {
+	var ownerID int64
+	err := r.db.QueryRowContext(ctx, `SELECT user_id FROM words WHERE id = ?`, wordID).Scan(&ownerID)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("word %d: %w", wordID, domain.ErrWordNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check word owner: %w", err)
+	}
+
+	return fmt.Errorf("word %d of user %d requested by user %d: %w", wordID, ownerID, userID, domain.ErrWordForbidden)
+}
+
+// GetRandomTranslations подбирает переводы других слов пользователя для вариантов ответа.
+// В первую очередь берутся слова того же языка и той же части речи.
+func (r *wordRepository) GetRandomTranslations(ctx context.Context, word *domain.Word, limit int) ([]string, error) {
+	return r.getRandomValues(ctx, "translation", word, limit)
+}
+
+// GetRandomOriginals - то же для обратных карточек, где ответом служит оригинал
+func (r *wordRepository) GetRandomOriginals(ctx context.Context, word *domain.Word, limit int) ([]string, error) {
+	return r.getRandomValues(ctx, "original", word, limit)
+}
+
+func (r *wordRepository) getRandomValues(ctx context.Context, column string, word *domain.Word, limit int) ([]string, error) {
 	query := `
-        SELECT translation FROM words
-        WHERE user_id = ? AND translation != ?
-        ORDER BY RANDOM()
+        SELECT ` + column + ` FROM words
+        WHERE user_id = ? AND id != ?
+        ORDER BY language = ? DESC, part_of_speech = ? DESC, RANDOM()
         LIMIT ?
     `
 
-	rows, err := r.db.QueryContext(ctx, query, userID, exclude, limit)
+	rows, err := r.db.QueryContext(ctx, query, word.UserID, word.ID, word.Language, word.PartOfSpeech, limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get random translations: %w", err)
+		return nil, fmt.Errorf("failed to get random %ss: %w", column, err)
 	}
 	defer rows.Close()
 
-	var translations []string
+	var values []string
 	for rows.Next() {
-		var translation string
-		if err := rows.Scan(&translation); err != nil {
-			return nil, fmt.Errorf("failed to scan translation: %w", err)
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", column, err)
 		}
-		translations = append(translations, translation)
+		values = append(values, value)
 	}
 
-	return translations, nil
+	return values, nil
 }
 
 func (r *wordRepository) updateUserWordStats(ctx context.Context, userID int64) error {
@@ -323,3 +456,132 @@ func (r *wordRepository) updateUserWordStats(ctx context.Context, userID int64)
 	_, err := r.db.ExecContext(ctx, query, userID, userID, time.Now(), userID)
 	return err
 }
+
+// saveTranslations перезаписывает список допустимых переводов слова
+func (r *wordRepository) saveTranslations(ctx context.Context, tx *sql.Tx, word *domain.Word) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM word_translations WHERE word_id = ?`, word.ID); err != nil {
+		return fmt.Errorf("failed to clear translations: %w", err)
+	}
+
+	query := `INSERT INTO word_translations (word_id, position, translation, normalized) VALUES (?, ?, ?, ?)`
+	for i, translation := range word.AcceptedTranslations() {
+		if _, err := tx.ExecContext(ctx, query, word.ID, i, translation, domain.SearchKey(translation)); err != nil {
+			return fmt.Errorf("failed to save translation: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// loadTranslations одним запросом подгружает переводы для списка слов
+func loadTranslations(ctx context.Context, db *sql.DB, words []*domain.Word) error {
+	if len(words) == 0 {
+		return nil
+	}
+
+	byID := make(map[int]*domain.Word, len(words))
+	placeholders := make([]string, 0, len(words))
+	args := make([]any, 0, len(words))
+	for _, word := range words {
+		byID[word.ID] = word
+		word.Translations = nil
+		placeholders = append(placeholders, "?")
+		args = append(args, word.ID)
+	}
+
+	query := `
+        SELECT word_id, translation FROM word_translations
+        WHERE word_id IN (` + strings.Join(placeholders, ", ") + `)
+        ORDER BY word_id, position
+    `
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to load translations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var wordID int
+		var translation string
+		if err := rows.Scan(&wordID, &translation); err != nil {
+			return fmt.Errorf("failed to scan translation: %w", err)
+		}
+
+		if word, ok := byID[wordID]; ok {
+			word.Translations = append(word.Translations, translation)
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *wordRepository) scanWordsWithTranslations(ctx context.Context, rows *sql.Rows) ([]*domain.Word, error) {
+	words, err := scanWords(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := loadTranslations(ctx, r.db, words); err != nil {
+		return nil, err
+	}
+
+	return words, nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanWord(row rowScanner) (*domain.Word, error) {
+	var word domain.Word
+	var lastReviewedAt sql.NullTime
+
+	err := row.Scan(
+		&word.ID,
+		&word.UserID,
+		&word.Original,
+		&word.Translation,
+		&word.Language,
+		&word.PartOfSpeech,
+		&word.Example,
+		&word.Difficulty,
+		&word.NextReview,
+		&word.ReviewCount,
+		&word.CorrectAnswers,
+		&word.CreatedAt,
+		&word.UpdatedAt,
+		&word.IntervalDays,
+		&lastReviewedAt,
+		&word.Stability,
+		&word.FSRSDifficulty,
+		&word.Retrievability,
+		&word.DeckID,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if lastReviewedAt.Valid {
+		word.LastReviewedAt = lastReviewedAt.Time
+	}
+
+	return &word, nil
+}
+
+func scanWords(rows *sql.Rows) ([]*domain.Word, error) {
+	var words []*domain.Word
+	for rows.Next() {
+		word, err := scanWord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan word: %w", err)
+		}
+		words = append(words, word)
+	}
+
+	return words, nil
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
@@ -0,0 +1,204 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"ivanSaichkin/language-bot/internal/domain"
+)
+
+const (
+	ownerID    int64 = 1
+	strangerID int64 = 2
+)
+
+func newTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec("PRAGMA foreign_keys = ON"); err != nil {
+		t.Fatalf("enable foreign keys: %v", err)
+	}
+	if err := initSQLiteSchema(db); err != nil {
+		t.Fatalf("init schema: %v", err)
+	}
+
+	return db
+}
+
+// newOwnedWord создаёт двух пользователей и слово первого из них
+func newOwnedWord(t *testing.T, db *sql.DB) (WordRepository, *domain.Word) {
+	t.Helper()
+	ctx := context.Background()
+
+	users := NewUserRepository(db)
+	for _, id := range []int64{ownerID, strangerID} {
+		if err := users.Create(ctx, domain.NewUser(id, "user", "User", "", "ru")); err != nil {
+			t.Fatalf("create user %d: %v", id, err)
+		}
+	}
+
+	words := NewWordRepository(db)
+	word := domain.NewWord(ownerID, "house", "дом", "en")
+	word.SetTranslations([]string{"дом", "здание"})
+	if err := words.Create(ctx, word); err != nil {
+		t.Fatalf("create word: %v", err)
+	}
+
+	return words, word
+}
+
+func TestWordRepositoryGetByID(t *testing.T) {
+	words, word := newOwnedWord(t, newTestDB(t))
+	ctx := context.Background()
+
+	got, err := words.GetByID(ctx, ownerID, word.ID)
+	if err != nil {
+		t.Fatalf("owner: unexpected error: %v", err)
+	}
+	if got.Original != "house" || len(got.AcceptedTranslations()) != 2 {
+		t.Errorf("owner: got %q %v", got.Original, got.AcceptedTranslations())
+	}
+
+	if _, err := words.GetByID(ctx, strangerID, word.ID); !errors.Is(err, domain.ErrWordForbidden) {
+		t.Errorf("stranger: got %v, want ErrWordForbidden", err)
+	}
+
+	if _, err := words.GetByID(ctx, ownerID, word.ID+100); !errors.Is(err, domain.ErrWordNotFound) {
+		t.Errorf("missing: got %v, want ErrWordNotFound", err)
+	}
+}
+
+func TestWordRepositoryUpdate(t *testing.T) {
+	words, word := newOwnedWord(t, newTestDB(t))
+	ctx := context.Background()
+
+	stolen := *word
+	stolen.Original = "stolen"
+	if err := words.Update(ctx, strangerID, &stolen); !errors.Is(err, domain.ErrWordForbidden) {
+		t.Fatalf("stranger: got %v, want ErrWordForbidden", err)
+	}
+
+	got, err := words.GetByID(ctx, ownerID, word.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got.Original != "house" {
+		t.Errorf("stranger changed the word: %q", got.Original)
+	}
+
+	word.Original = "home"
+	if err := words.Update(ctx, ownerID, word); err != nil {
+		t.Fatalf("owner: unexpected error: %v", err)
+	}
+	if got, _ := words.GetByID(ctx, ownerID, word.ID); got.Original != "home" {
+		t.Errorf("owner: got %q, want %q", got.Original, "home")
+	}
+
+	missing := *word
+	missing.ID += 100
+	if err := words.Update(ctx, ownerID, &missing); !errors.Is(err, domain.ErrWordNotFound) {
+		t.Errorf("missing: got %v, want ErrWordNotFound", err)
+	}
+}
+
+func TestWordRepositoryMoveToDeck(t *testing.T) {
+	db := newTestDB(t)
+	words, word := newOwnedWord(t, db)
+	ctx := context.Background()
+
+	deck := domain.NewDeck(ownerID, "Дом")
+	if err := NewDeckRepository(db).Create(ctx, deck); err != nil {
+		t.Fatalf("create deck: %v", err)
+	}
+
+	if err := words.MoveToDeck(ctx, strangerID, word.ID, deck.ID); !errors.Is(err, domain.ErrWordForbidden) {
+		t.Fatalf("stranger: got %v, want ErrWordForbidden", err)
+	}
+
+	if err := words.MoveToDeck(ctx, ownerID, word.ID, deck.ID); err != nil {
+		t.Fatalf("owner: unexpected error: %v", err)
+	}
+	if got, _ := words.GetByID(ctx, ownerID, word.ID); got.DeckID != deck.ID {
+		t.Errorf("owner: deck %d, want %d", got.DeckID, deck.ID)
+	}
+}
+
+func TestWordRepositoryDelete(t *testing.T) {
+	db := newTestDB(t)
+	words, word := newOwnedWord(t, db)
+	ctx := context.Background()
+
+	if err := words.Delete(ctx, strangerID, word.ID); !errors.Is(err, domain.ErrWordForbidden) {
+		t.Fatalf("stranger: got %v, want ErrWordForbidden", err)
+	}
+	if _, err := words.GetByID(ctx, ownerID, word.ID); err != nil {
+		t.Fatalf("stranger deleted the word: %v", err)
+	}
+
+	if err := words.Delete(ctx, ownerID, word.ID); err != nil {
+		t.Fatalf("owner: unexpected error: %v", err)
+	}
+	if _, err := words.GetByID(ctx, ownerID, word.ID); !errors.Is(err, domain.ErrWordNotFound) {
+		t.Errorf("after delete: got %v, want ErrWordNotFound", err)
+	}
+
+	var translations int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM word_translations WHERE word_id = ?`, word.ID).Scan(&translations); err != nil {
+		t.Fatalf("count translations: %v", err)
+	}
+	if translations != 0 {
+		t.Errorf("after delete: %d translations left", translations)
+	}
+
+	if err := words.Delete(ctx, ownerID, word.ID); !errors.Is(err, domain.ErrWordNotFound) {
+		t.Errorf("second delete: got %v, want ErrWordNotFound", err)
+	}
+}
+
+func TestWordRepositoryFindByOriginal(t *testing.T) {
+	db := newTestDB(t)
+	repo, house := newOwnedWord(t, db)
+	ctx := context.Background()
+
+	cafe := domain.NewWord(ownerID, "Café", "кафе", "fr")
+	if err := repo.Create(ctx, cafe); err != nil {
+		t.Fatalf("create word: %v", err)
+	}
+
+	tests := []struct {
+		userID   int64
+		language string
+		keys     []string
+		want     []int
+	}{
+		{ownerID, "en", []string{"house", "horse"}, []int{house.ID}},
+		{ownerID, "fr", []string{domain.SearchKey("CAFÉ")}, []int{cafe.ID}},
+		{ownerID, "en", []string{"cafe"}, nil},
+		{strangerID, "en", []string{"house"}, nil},
+	}
+
+	for _, tt := range tests {
+		words, err := repo.FindByOriginal(ctx, tt.userID, tt.language, tt.keys)
+		if err != nil {
+			t.Fatalf("%v: find by original: %v", tt.keys, err)
+		}
+
+		var got []int
+		for _, word := range words {
+			got = append(got, word.ID)
+		}
+		if len(got) != len(tt.want) || (len(got) > 0 && got[0] != tt.want[0]) {
+			t.Errorf("user %d %s %v: got %v, want %v", tt.userID, tt.language, tt.keys, got, tt.want)
+		}
+	}
+}
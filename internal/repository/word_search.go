@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"ivanSaichkin/language-bot/internal/domain"
+)
+
+// Полнотекстовый индекс слов. FTS5 есть в go-sqlite3 только при сборке с тегом sqlite_fts5,
+// его ставят цели Makefile (make build, make test):
+//
+//	go build -tags sqlite_fts5 ./cmd/bot
+//
+// Без тега поиск работает через LIKE, а триггеры индекса удаляются, чтобы не ломать запись слов.
+var wordSearchTriggers = map[string]string{
+	"words_fts_insert":        `AFTER INSERT ON words BEGIN ` + wordSearchRefresh("NEW.id") + ` END`,
+	"words_fts_update":        `AFTER UPDATE OF normalized_original, normalized_example ON words BEGIN ` + wordSearchRefresh("NEW.id") + ` END`,
+	"words_fts_delete":        `AFTER DELETE ON words BEGIN DELETE FROM words_fts WHERE rowid = OLD.id; END`,
+	"translations_fts_insert": `AFTER INSERT ON word_translations BEGIN ` + wordSearchRefresh("NEW.word_id") + ` END`,
+	"translations_fts_delete": `AFTER DELETE ON word_translations BEGIN ` + wordSearchRefresh("OLD.word_id") + ` END`,
+}
+
+// wordSearchIndexRows - строки индекса: ключи поиска оригинала, всех переводов и примера,
+// те же, по которым ищет LIKE без FTS5
+const wordSearchIndexRows = `
+        SELECT id, normalized_original,
+               COALESCE((SELECT group_concat(normalized, ' ') FROM word_translations WHERE word_id = words.id), ''),
+               normalized_example
+        FROM words`
+
+// wordSearchRefresh пересобирает строку индекса для слова
+func wordSearchRefresh(wordID string) string {
+	return `
+        DELETE FROM words_fts WHERE rowid = ` + wordID + `;
+        INSERT INTO words_fts (rowid, original, translations, example)` + wordSearchIndexRows + ` WHERE id = ` + wordID + `;`
+}
+
+// initWordSearchIndex создаёт индекс и триггеры синхронизации. Индекс заполняется,
+// только когда он создан впервые или триггеры были удалены сборкой без FTS5 -
+// в остальное время его поддерживают триггеры.
+func initWordSearchIndex(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE VIRTUAL TABLE temp.fts5_probe USING fts5(text)`); err != nil {
+		for name := range wordSearchTriggers {
+			if _, dropErr := db.Exec(`DROP TRIGGER IF EXISTS ` + name); dropErr != nil {
+				return fmt.Errorf("failed to drop trigger %s: %w", name, dropErr)
+			}
+		}
+		return fmt.Errorf("fts5 is not available: %w", err)
+	}
+	if _, err := db.Exec(`DROP TABLE temp.fts5_probe`); err != nil {
+		return fmt.Errorf("failed to drop fts5 probe: %w", err)
+	}
+
+	var synced int
+	err := db.QueryRow(`
+        SELECT COUNT(*) FROM sqlite_master
+        WHERE (type = 'table' AND name = 'words_fts') OR (type = 'trigger' AND name = 'words_fts_insert')
+    `).Scan(&synced)
+	if err != nil {
+		return fmt.Errorf("failed to check word search index: %w", err)
+	}
+
+	statements := []string{
+		`CREATE VIRTUAL TABLE IF NOT EXISTS words_fts USING fts5(
+            original, translations, example,
+            tokenize = 'unicode61 remove_diacritics 2'
+        )`,
+	}
+	for name, body := range wordSearchTriggers {
+		statements = append(statements, `CREATE TRIGGER IF NOT EXISTS `+name+` `+body)
+	}
+	// Индекса ещё нет или он отстал от слов, записанных без триггеров
+	if synced < 2 {
+		statements = append(statements,
+			`DELETE FROM words_fts`,
+			`INSERT INTO words_fts (rowid, original, translations, example)`+wordSearchIndexRows,
+		)
+	}
+
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			return fmt.Errorf("failed to init word search index: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// backfillSearchKeys заполняет ключи поиска у слов, записанных до их появления.
+// Переводы таких слов хранили ключ без свёртки диакритики - он пересчитывается тоже.
+func backfillSearchKeys(db *sql.DB) error {
+	type wordKeys struct {
+		id       int
+		original string
+		example  string
+	}
+
+	rows, err := db.Query(`SELECT id, original, example FROM words WHERE normalized_original = ''`)
+	if err != nil {
+		return fmt.Errorf("failed to get words without search keys: %w", err)
+	}
+
+	// Соединение одно: сначала дочитываем слова, потом открываем транзакцию
+	var words []wordKeys
+	for rows.Next() {
+		var word wordKeys
+		if err := rows.Scan(&word.id, &word.original, &word.example); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan word: %w", err)
+		}
+		words = append(words, word)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate words: %w", err)
+	}
+
+	if len(words) == 0 {
+		return nil
+	}
+
+	translations := make(map[int]string)
+	rows, err = db.Query(`
+        SELECT t.id, t.translation FROM word_translations t
+        JOIN words w ON w.id = t.word_id
+        WHERE w.normalized_original = ''
+    `)
+	if err != nil {
+		return fmt.Errorf("failed to get translations without search keys: %w", err)
+	}
+	for rows.Next() {
+		var id int
+		var translation string
+		if err := rows.Scan(&id, &translation); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan translation: %w", err)
+		}
+		translations[id] = translation
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate translations: %w", err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, word := range words {
+		_, err := tx.Exec(`UPDATE words SET normalized_original = ?, normalized_example = ? WHERE id = ?`,
+			domain.SearchKey(word.original), domain.SearchKey(word.example), word.id)
+		if err != nil {
+			return fmt.Errorf("failed to save word search keys: %w", err)
+		}
+	}
+
+	for id, translation := range translations {
+		if _, err := tx.Exec(`UPDATE word_translations SET normalized = ? WHERE id = ?`, domain.SearchKey(translation), id); err != nil {
+			return fmt.Errorf("failed to save translation search key: %w", err)
+		}
+	}
+
+	// Индекс был построен по исходному тексту: без триггеров initWordSearchIndex
+	// создаст их заново и перезаполнит индекс ключами поиска
+	for name := range wordSearchTriggers {
+		if _, err := tx.Exec(`DROP TRIGGER IF EXISTS ` + name); err != nil {
+			return fmt.Errorf("failed to drop trigger %s: %w", name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit search keys: %w", err)
+	}
+
+	log.Printf("🔎 Filled search keys for %d words", len(words))
+	return nil
+}
+
+// hasWordSearchIndex сообщает, что индекс создан и поддерживается триггерами
+func hasWordSearchIndex(db *sql.DB) bool {
+	var count int
+	err := db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'trigger' AND name = 'words_fts_insert'`).Scan(&count)
+	if err != nil {
+		log.Printf("⚠️ Failed to check word search index: %v", err)
+		return false
+	}
+
+	return count > 0
+}
+
+// Search ищет слова пользователя по началу слов оригинала, переводов и примера
+// без учёта регистра и диакритики; лучшие совпадения идут первыми
+func (r *wordRepository) Search(ctx context.Context, userID int64, query string, limit int) ([]*domain.Word, error) {
+	terms := strings.Fields(domain.SearchKey(query))
+	if len(terms) == 0 {
+		return nil, nil
+	}
+
+	if limit <= 0 {
+		limit = 10
+	}
+
+	var rows *sql.Rows
+	var err error
+	if r.searchIndex {
+		// Каждое слово запроса - префикс в кавычках, чтобы символы запроса не разбирались как синтаксис FTS
+		for i, term := range terms {
+			terms[i] = `"` + term + `"*`
+		}
+
+		rows, err = r.db.QueryContext(ctx, `
+            SELECT `+wordColumns+`
+            FROM words
+            JOIN (
+                SELECT rowid AS fts_id, bm25(words_fts) AS rank
+                FROM words_fts WHERE words_fts MATCH ?
+            ) ON fts_id = words.id
+            WHERE user_id = ?
+            ORDER BY rank
+            LIMIT ?
+        `, strings.Join(terms, " "), userID, limit)
+	} else {
+		// Ключи поиска уже без регистра и диакритики: lower() в SQLite понимает только ASCII
+		pattern := "%" + strings.Join(terms, " ") + "%"
+		rows, err = r.db.QueryContext(ctx, `
+            SELECT `+wordColumns+`
+            FROM words
+            WHERE user_id = ?
+              AND (normalized_original LIKE ? OR normalized_example LIKE ?
+                   OR id IN (SELECT word_id FROM word_translations WHERE normalized LIKE ?))
+            ORDER BY original
+            LIMIT ?
+        `, userID, pattern, pattern, pattern, limit)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to search words: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanWordsWithTranslations(ctx, rows)
+}
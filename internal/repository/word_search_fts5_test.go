@@ -0,0 +1,87 @@
+//go:build sqlite_fts5
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"ivanSaichkin/language-bot/internal/domain"
+)
+
+func TestWordSearchIndexSync(t *testing.T) {
+	db := newTestDB(t)
+	if !hasWordSearchIndex(db) {
+		t.Fatal("word search index was not created")
+	}
+
+	words, word := newOwnedWord(t, db)
+	ctx := context.Background()
+
+	cafe := domain.NewWord(ownerID, "Café", "кафе", "fr")
+	if err := words.Create(ctx, cafe); err != nil {
+		t.Fatalf("create word: %v", err)
+	}
+	if got := searchOriginals(t, words, ownerID, "cafe"); len(got) != 1 {
+		t.Errorf("diacritics: got %v, want [Café]", got)
+	}
+
+	word.Original = "home"
+	word.SetTranslations([]string{"жилище"})
+	if err := words.Update(ctx, ownerID, word); err != nil {
+		t.Fatalf("update word: %v", err)
+	}
+
+	if got := searchOriginals(t, words, ownerID, "house"); len(got) != 0 {
+		t.Errorf("old original is still indexed: %v", got)
+	}
+	if got := searchOriginals(t, words, ownerID, "здание"); len(got) != 0 {
+		t.Errorf("old translation is still indexed: %v", got)
+	}
+	if got := searchOriginals(t, words, ownerID, "жилищ"); len(got) != 1 || got[0] != "home" {
+		t.Errorf("new translation: got %v, want [home]", got)
+	}
+
+	if err := words.Delete(ctx, ownerID, word.ID); err != nil {
+		t.Fatalf("delete word: %v", err)
+	}
+	if got := searchOriginals(t, words, ownerID, "home"); len(got) != 0 {
+		t.Errorf("deleted word is still indexed: %v", got)
+	}
+}
+
+func TestWordSearchIndexNotRebuilt(t *testing.T) {
+	db := newTestDB(t)
+	words, _ := newOwnedWord(t, db)
+
+	// Строка, которой нет среди слов: полная пересборка индекса её бы удалила
+	if _, err := db.Exec(`INSERT INTO words_fts (rowid, original, translations, example) VALUES (999, 'marker', '', '')`); err != nil {
+		t.Fatalf("insert marker: %v", err)
+	}
+
+	if err := initWordSearchIndex(db); err != nil {
+		t.Fatalf("init index: %v", err)
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM words_fts WHERE rowid = 999`).Scan(&count); err != nil {
+		t.Fatalf("count marker: %v", err)
+	}
+	if count != 1 {
+		t.Error("index was rebuilt although triggers kept it in sync")
+	}
+
+	// Без триггеров индекс мог отстать - тогда он пересобирается
+	if _, err := db.Exec(`DROP TRIGGER words_fts_insert`); err != nil {
+		t.Fatalf("drop trigger: %v", err)
+	}
+	if err := initWordSearchIndex(db); err != nil {
+		t.Fatalf("init index: %v", err)
+	}
+	if got := searchOriginals(t, words, ownerID, "marker"); len(got) != 0 {
+		t.Errorf("stale row survived the rebuild: %v", got)
+	}
+	if got := searchOriginals(t, words, ownerID, "house"); len(got) != 1 {
+		t.Errorf("after rebuild: got %v, want [house]", got)
+	}
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"ivanSaichkin/language-bot/internal/domain"
+)
+
+// newSearchWords добавляет первому пользователю несколько слов, второму - одно
+func newSearchWords(t *testing.T) WordRepository {
+	t.Helper()
+	ctx := context.Background()
+
+	words, _ := newOwnedWord(t, newTestDB(t))
+	for _, word := range []*domain.Word{
+		domain.NewWord(ownerID, "garden", "сад", "en").WithExample("We sit in the garden"),
+		domain.NewWord(ownerID, "window", "окно", "en"),
+		domain.NewWord(ownerID, "Straße", "Улица", "de"),
+		domain.NewWord(strangerID, "garage", "гараж", "en"),
+	} {
+		if err := words.Create(ctx, word); err != nil {
+			t.Fatalf("create word %q: %v", word.Original, err)
+		}
+	}
+
+	return words
+}
+
+func searchOriginals(t *testing.T, words WordRepository, userID int64, query string) []string {
+	t.Helper()
+
+	found, err := words.Search(context.Background(), userID, query, 10)
+	if err != nil {
+		t.Fatalf("search %q: %v", query, err)
+	}
+
+	originals := make([]string, len(found))
+	for i, word := range found {
+		originals[i] = word.Original
+	}
+
+	return originals
+}
+
+func TestWordRepositorySearch(t *testing.T) {
+	words := newSearchWords(t)
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"garden", []string{"garden"}},
+		{"GARD", []string{"garden"}},
+		{"здание", []string{"house"}},
+		{"sit", []string{"garden"}},
+		{"ЗДАН", []string{"house"}},
+		{"улиц", []string{"Straße"}},
+		{"strasse", []string{"Straße"}},
+		{"garage", nil},
+		{"  ", nil},
+	}
+
+	for _, tt := range tests {
+		got := searchOriginals(t, words, ownerID, tt.query)
+		if len(got) != len(tt.want) || (len(got) > 0 && got[0] != tt.want[0]) {
+			t.Errorf("%q: got %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestBackfillSearchKeys(t *testing.T) {
+	db := newTestDB(t)
+	words, _ := newOwnedWord(t, db)
+
+	// Слово, записанное до появления ключей поиска
+	if _, err := db.Exec(`UPDATE words SET normalized_original = '', normalized_example = ''`); err != nil {
+		t.Fatalf("clear search keys: %v", err)
+	}
+	if _, err := db.Exec(`UPDATE word_translations SET normalized = 'ЗДАНИЕ' WHERE translation = 'здание'`); err != nil {
+		t.Fatalf("clear translation key: %v", err)
+	}
+
+	if err := backfillSearchKeys(db); err != nil {
+		t.Fatalf("backfill: %v", err)
+	}
+
+	var original, translation string
+	if err := db.QueryRow(`SELECT normalized_original FROM words`).Scan(&original); err != nil {
+		t.Fatalf("read word key: %v", err)
+	}
+	if err := db.QueryRow(`SELECT normalized FROM word_translations WHERE translation = 'здание'`).Scan(&translation); err != nil {
+		t.Fatalf("read translation key: %v", err)
+	}
+	if original != "house" || translation != "здание" {
+		t.Errorf("got keys %q and %q, want house and здание", original, translation)
+	}
+
+	if got := searchOriginals(t, words, ownerID, "здан"); len(got) != 1 {
+		t.Errorf("search after backfill: got %v, want [house]", got)
+	}
+}
@@ -0,0 +1,368 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"ivanSaichkin/language-bot/internal/domain"
+)
+
+// MaxAnkiFileSize - больше бот всё равно не скачает через Bot API
+const MaxAnkiFileSize = 20 << 20
+
+// Очереди карточек Anki: от очереди зависит, в чём записан due
+const (
+	ankiQueueLearning    = 1 // unix-время
+	ankiQueueReview      = 2 // номер дня от создания коллекции
+	ankiQueueDayLearning = 3 // номер дня, шаг изучения длиннее суток
+)
+
+// ankiCardNew - карточка без расписания
+const ankiCardNew = 0
+
+// Отложенные и приостановленные карточки хранят due в прежнем формате:
+// номера дней заметно меньше unix-времени
+const ankiMaxDayNumber = 1_000_000
+
+var (
+	ankiTagPattern   = regexp.MustCompile(`<[^>]*>`)
+	ankiBreakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</div>|</p>|</li>`)
+	ankiSoundPattern = regexp.MustCompile(`\[sound:[^\]]*\]`)
+)
+
+// ParseAnki читает коллекцию из .apkg: модели заметок с полями, заметки и
+// расписание первой карточки каждой заметки. Медиафайлы не импортируются.
+func (s *importService) ParseAnki(data []byte) (*AnkiPackage, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open apkg: %w", err)
+	}
+
+	// В новых пакетах collection.anki2 - заглушка, настоящие данные в collection.anki21
+	files := make(map[string]*zip.File)
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	collection := files["collection.anki21"]
+	if collection == nil {
+		collection = files["collection.anki2"]
+	}
+	if collection == nil {
+		if files["collection.anki21b"] != nil {
+			return nil, fmt.Errorf("compressed anki21b collections are not supported")
+		}
+		return nil, fmt.Errorf("apkg has no collection")
+	}
+
+	path, err := extractAnkiCollection(collection)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(path)
+
+	db, err := sql.Open("sqlite3", "file:"+path+"?mode=ro")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open anki collection: %w", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	var created int64
+	var modelsJSON string
+	if err := db.QueryRow(`SELECT crt, models FROM col`).Scan(&created, &modelsJSON); err != nil {
+		return nil, fmt.Errorf("failed to read anki collection: %w", err)
+	}
+
+	models, err := readAnkiModels(db, modelsJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := readAnkiCards(db, time.Unix(created, 0))
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := &AnkiPackage{Models: models}
+	rows, err := db.Query(`SELECT id, mid, flds FROM notes ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read anki notes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, modelID int64
+		var fields string
+		if err := rows.Scan(&id, &modelID, &fields); err != nil {
+			return nil, fmt.Errorf("failed to scan anki note: %w", err)
+		}
+
+		if len(pkg.Notes) >= MaxImportRows {
+			return nil, fmt.Errorf("too many notes, max %d", MaxImportRows)
+		}
+
+		pkg.Notes = append(pkg.Notes, &AnkiNote{
+			Number:  len(pkg.Notes) + 1,
+			ModelID: modelID,
+			Fields:  strings.Split(fields, "\x1f"),
+			Card:    cards[id],
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read anki notes: %w", err)
+	}
+
+	return pkg, nil
+}
+
+// PreviewAnki превращает заметки в слова по сопоставлению полей каждой модели
+func (s *importService) PreviewAnki(ctx context.Context, userID int64, pkg *AnkiPackage, options AnkiImportOptions) (*ImportPreview, error) {
+	language, err := s.defaultLanguage(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	models := make(map[int64]*AnkiModel, len(pkg.Models))
+	for _, model := range pkg.Models {
+		models[model.ID] = model
+	}
+
+	preview := &ImportPreview{Format: "Anki"}
+	for _, note := range pkg.Notes {
+		model := models[note.ModelID]
+		if model == nil {
+			preview.Errors = append(preview.Errors, ImportRowError{Line: note.Number, Reason: ImportErrorMissingFields})
+			continue
+		}
+
+		mapping := model.Mapping
+		original := ankiField(note.Fields, mapping.Original)
+		translations := splitImportList(ankiField(note.Fields, mapping.Translation))
+		if original == "" || len(translations) == 0 {
+			preview.Errors = append(preview.Errors, ImportRowError{Line: note.Number, Reason: ImportErrorMissingFields})
+			continue
+		}
+
+		word := domain.NewWord(userID, original, translations[0], language)
+		word.SetTranslations(translations)
+		if example := ankiField(note.Fields, mapping.Example); example != "" {
+			word.WithExample(example)
+		}
+
+		if options.KeepSchedule && note.Card != nil {
+			card := note.Card
+			word.ApplyImportedSchedule(card.IntervalDays, card.Ease, card.Due, card.Reviews, card.Reviews-card.Lapses)
+		}
+
+		preview.Rows = append(preview.Rows, &ImportRow{Line: note.Number, Word: word})
+	}
+
+	if err := s.countDuplicates(ctx, userID, preview); err != nil {
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+func extractAnkiCollection(file *zip.File) (string, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open anki collection: %w", err)
+	}
+	defer reader.Close()
+
+	tmp, err := os.CreateTemp("", "anki-*.db")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer tmp.Close()
+
+	// Распакованная коллекция может быть заметно больше архива
+	if _, err := io.Copy(tmp, io.LimitReader(reader, 10*MaxAnkiFileSize)); err != nil {
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("failed to extract anki collection: %w", err)
+	}
+
+	return tmp.Name(), nil
+}
+
+// readAnkiModels читает модели заметок: в старой схеме - JSON из col.models,
+// в новой - таблицы notetypes и fields
+func readAnkiModels(db *sql.DB, modelsJSON string) ([]*AnkiModel, error) {
+	var raw map[string]struct {
+		Name   string `json:"name"`
+		Fields []struct {
+			Name string `json:"name"`
+			Ord  int    `json:"ord"`
+		} `json:"flds"`
+	}
+	if modelsJSON != "" {
+		if err := json.Unmarshal([]byte(modelsJSON), &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse anki models: %w", err)
+		}
+	}
+
+	var models []*AnkiModel
+	for id, model := range raw {
+		var modelID int64
+		if _, err := fmt.Sscan(id, &modelID); err != nil {
+			continue
+		}
+
+		fields := make([]string, len(model.Fields))
+		for _, field := range model.Fields {
+			if field.Ord >= 0 && field.Ord < len(fields) {
+				fields[field.Ord] = field.Name
+			}
+		}
+		models = append(models, newAnkiModel(modelID, model.Name, fields))
+	}
+
+	if len(models) > 0 {
+		sort.Slice(models, func(i, j int) bool { return models[i].ID < models[j].ID })
+		return models, nil
+	}
+
+	return readAnkiNotetypes(db)
+}
+
+func readAnkiNotetypes(db *sql.DB) ([]*AnkiModel, error) {
+	rows, err := db.Query(`
+        SELECT n.id, n.name, f.name
+        FROM notetypes n JOIN fields f ON f.ntid = n.id
+        ORDER BY n.id, f.ord
+    `)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read anki note types: %w", err)
+	}
+	defer rows.Close()
+
+	var models []*AnkiModel
+	var current *AnkiModel
+	for rows.Next() {
+		var id int64
+		var name, field string
+		if err := rows.Scan(&id, &name, &field); err != nil {
+			return nil, fmt.Errorf("failed to scan anki note type: %w", err)
+		}
+
+		if current == nil || current.ID != id {
+			current = &AnkiModel{ID: id, Name: name}
+			models = append(models, current)
+		}
+		current.Fields = append(current.Fields, field)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read anki note types: %w", err)
+	}
+
+	for _, model := range models {
+		model.Mapping = defaultAnkiMapping(model.Fields)
+	}
+
+	return models, nil
+}
+
+// readAnkiCards возвращает расписание первой карточки каждой заметки
+func readAnkiCards(db *sql.DB, created time.Time) (map[int64]*AnkiCard, error) {
+	rows, err := db.Query(`
+        SELECT nid, type, queue, due, ivl, factor, reps, lapses
+        FROM cards
+        ORDER BY nid, ord
+    `)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read anki cards: %w", err)
+	}
+	defer rows.Close()
+
+	cards := make(map[int64]*AnkiCard)
+	for rows.Next() {
+		var noteID, due, interval int64
+		var cardType, queue, factor, reps, lapses int
+		if err := rows.Scan(&noteID, &cardType, &queue, &due, &interval, &factor, &reps, &lapses); err != nil {
+			return nil, fmt.Errorf("failed to scan anki card: %w", err)
+		}
+
+		if _, exists := cards[noteID]; exists {
+			continue
+		}
+
+		if cardType == ankiCardNew {
+			continue
+		}
+
+		card := &AnkiCard{Reviews: reps, Lapses: lapses, Ease: float64(factor) / 1000}
+		switch {
+		case queue == ankiQueueReview || queue == ankiQueueDayLearning:
+			card.Due = created.AddDate(0, 0, int(due))
+		case queue == ankiQueueLearning:
+			card.Due = time.Unix(due, 0)
+		case queue < 0 && due < ankiMaxDayNumber:
+			card.Due = created.AddDate(0, 0, int(due))
+		case queue < 0:
+			card.Due = time.Unix(due, 0)
+		default:
+			continue
+		}
+
+		// Отрицательный ivl - шаг изучения в секундах
+		card.IntervalDays = float64(interval)
+		if interval < 0 {
+			card.IntervalDays = float64(-interval) / 86400
+		}
+
+		cards[noteID] = card
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read anki cards: %w", err)
+	}
+
+	return cards, nil
+}
+
+func newAnkiModel(id int64, name string, fields []string) *AnkiModel {
+	return &AnkiModel{ID: id, Name: name, Fields: fields, Mapping: defaultAnkiMapping(fields)}
+}
+
+// defaultAnkiMapping: первое поле - слово, второе - перевод, пример - поле с подходящим названием
+func defaultAnkiMapping(fields []string) AnkiFieldMapping {
+	mapping := AnkiFieldMapping{Original: 0, Translation: 1, Example: -1}
+	for i := 2; i < len(fields); i++ {
+		name := strings.ToLower(fields[i])
+		if strings.Contains(name, "example") || strings.Contains(name, "sentence") || strings.Contains(name, "пример") {
+			mapping.Example = i
+			break
+		}
+	}
+
+	return mapping
+}
+
+// ankiField возвращает текст поля без HTML-разметки и звуковых вставок
+func ankiField(fields []string, index int) string {
+	if index < 0 || index >= len(fields) {
+		return ""
+	}
+
+	text := ankiSoundPattern.ReplaceAllString(fields[index], "")
+	text = ankiBreakPattern.ReplaceAllString(text, " ")
+	text = ankiTagPattern.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	return strings.Join(strings.Fields(text), " ")
+}
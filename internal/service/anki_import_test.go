@@ -0,0 +1,58 @@
+package service
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestReadAnkiCardsDue(t *testing.T) {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "collection.anki2"))
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`
+        CREATE TABLE cards (nid INTEGER, ord INTEGER, type INTEGER, queue INTEGER,
+                            due INTEGER, ivl INTEGER, factor INTEGER, reps INTEGER, lapses INTEGER);
+        INSERT INTO cards VALUES
+            (1, 0, 2, 2, 10, 30, 2500, 5, 1),          -- повторение: день 10
+            (2, 0, 1, 3, 12, 0, 0, 1, 0),              -- изучение с шагом больше суток: день 12
+            (3, 0, 3, 1, 1700000000, 20, 2300, 8, 2),  -- переучивание внутри дня: unix-время
+            (4, 0, 2, -1, 15, 40, 2500, 9, 0),         -- приостановленная карточка на повторении
+            (5, 0, 0, 0, 1, 0, 0, 0, 0),               -- новая карточка без расписания
+            (1, 1, 2, 2, 99, 99, 2500, 1, 0);          -- вторая карточка заметки пропускается
+    `)
+	if err != nil {
+		t.Fatalf("create cards: %v", err)
+	}
+
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	cards, err := readAnkiCards(db, created)
+	if err != nil {
+		t.Fatalf("read cards: %v", err)
+	}
+
+	want := map[int64]time.Time{
+		1: created.AddDate(0, 0, 10),
+		2: created.AddDate(0, 0, 12),
+		3: time.Unix(1700000000, 0),
+		4: created.AddDate(0, 0, 15),
+	}
+	if len(cards) != len(want) {
+		t.Fatalf("got %d cards, want %d", len(cards), len(want))
+	}
+	for noteID, due := range want {
+		if card := cards[noteID]; card == nil || !card.Due.Equal(due) {
+			t.Errorf("note %d: got %+v, want due %v", noteID, card, due)
+		}
+	}
+
+	if cards[1].IntervalDays != 30 || cards[1].Ease != 2.5 {
+		t.Errorf("note 1: interval %v, ease %v", cards[1].IntervalDays, cards[1].Ease)
+	}
+}
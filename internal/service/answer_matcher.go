@@ -0,0 +1,130 @@
+package service
+
+import (
+	"ivanSaichkin/language-bot/internal/constants"
+	"ivanSaichkin/language-bot/internal/domain"
+)
+
+type MatchResult int
+
+const (
+	MatchWrong MatchResult = iota
+	MatchClose             // ответ засчитан, но написан с опечаткой или без диакритики
+	MatchExact
+)
+
+type AnswerMatcher interface {
+	Match(answer, expected, strictness string) MatchResult
+	MatchAny(answer string, expected []string, strictness string) (MatchResult, string)
+	Distance(a, b string) int
+}
+
+type answerMatcher struct{}
+
+func NewAnswerMatcher() AnswerMatcher {
+	return &answerMatcher{}
+}
+
+func (m *answerMatcher) Match(answer, expected, strictness string) MatchResult {
+	normalizedAnswer := domain.NormalizeText(answer)
+	normalizedExpected := domain.NormalizeText(expected)
+
+	if normalizedAnswer == "" {
+		return MatchWrong
+	}
+
+	if normalizedAnswer == normalizedExpected {
+		return MatchExact
+	}
+
+	if strictness == constants.StrictnessStrict {
+		return MatchWrong
+	}
+
+	foldedAnswer := domain.FoldDiacritics(normalizedAnswer)
+	foldedExpected := domain.FoldDiacritics(normalizedExpected)
+
+	if foldedAnswer == foldedExpected {
+		return MatchClose
+	}
+
+	allowed := allowedTypos(len([]rune(foldedExpected)), strictness)
+	if allowed > 0 && m.Distance(foldedAnswer, foldedExpected) <= allowed {
+		return MatchClose
+	}
+
+	return MatchWrong
+}
+
+// MatchAny сверяет ответ со всеми допустимыми переводами и возвращает
+// лучший результат вместе с вариантом, на который он пришёлся
+func (m *answerMatcher) MatchAny(answer string, expected []string, strictness string) (MatchResult, string) {
+	best, matched := MatchWrong, ""
+	for _, candidate := range expected {
+		if result := m.Match(answer, candidate, strictness); result > best {
+			best, matched = result, candidate
+			if best == MatchExact {
+				break
+			}
+		}
+	}
+
+	return best, matched
+}
+
+// Distance считает расстояние Дамерау-Левенштейна (вариант с ограниченными перестановками)
+func (m *answerMatcher) Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows, cols := len(ra)+1, len(rb)+1
+
+	d := make([][]int, rows)
+	for i := range d {
+		d[i] = make([]int, cols)
+		d[i][0] = i
+	}
+	for j := 0; j < cols; j++ {
+		d[0][j] = j
+	}
+
+	for i := 1; i < rows; i++ {
+		for j := 1; j < cols; j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[rows-1][cols-1]
+}
+
+// allowedTypos возвращает допустимое число опечаток в зависимости от длины слова
+func allowedTypos(length int, strictness string) int {
+	if strictness == constants.StrictnessLenient {
+		switch {
+		case length <= 2:
+			return 0
+		case length <= 5:
+			return 1
+		case length <= 9:
+			return 2
+		default:
+			return 3
+		}
+	}
+
+	switch {
+	case length <= 3:
+		return 0
+	case length <= 7:
+		return 1
+	default:
+		return 2
+	}
+}
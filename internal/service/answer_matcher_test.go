@@ -0,0 +1,125 @@
+package service
+
+import (
+	"testing"
+
+	"ivanSaichkin/language-bot/internal/constants"
+)
+
+// Одно и то же слово в разных формах Unicode
+const (
+	cafeNFC = "caf\u00e9"
+	cafeNFD = "cafe\u0301"
+)
+
+func TestAnswerMatcherMatch(t *testing.T) {
+	matcher := NewAnswerMatcher()
+
+	tests := []struct {
+		name       string
+		answer     string
+		expected   string
+		strictness string
+		want       MatchResult
+	}{
+		{"exact", "house", "house", constants.StrictnessNormal, MatchExact},
+		{"case and punctuation", " House! ", "house", constants.StrictnessStrict, MatchExact},
+		{"empty answer", " ", "house", constants.StrictnessLenient, MatchWrong},
+		{"NFD answer, NFC expected", cafeNFD, cafeNFC, constants.StrictnessStrict, MatchExact},
+		{"NFC answer, NFD expected", cafeNFC, cafeNFD, constants.StrictnessStrict, MatchExact},
+		{"missing accent, strict", "cafe", cafeNFC, constants.StrictnessStrict, MatchWrong},
+		{"missing accent, normal", "cafe", cafeNFC, constants.StrictnessNormal, MatchClose},
+		{"NFD й is not и", "мои\u0306", "мой", constants.StrictnessStrict, MatchExact},
+		{"и instead of й", "мои", "мой", constants.StrictnessNormal, MatchWrong},
+		{"ё folds to е", "еж", "ёж", constants.StrictnessNormal, MatchClose},
+		{"ł folds to l", "zloty", "złoty", constants.StrictnessNormal, MatchClose},
+		{"č and ž fold", "cerzen", "čeržen", constants.StrictnessNormal, MatchClose},
+		{"ş and ğ fold", "dogus", "doğuş", constants.StrictnessNormal, MatchClose},
+		{"typo, strict", "hosue", "house", constants.StrictnessStrict, MatchWrong},
+		{"transposition, normal", "hosue", "house", constants.StrictnessNormal, MatchClose},
+		{"two typos in short word, normal", "hiuse", "horse", constants.StrictnessNormal, MatchWrong},
+		{"two typos in long word, normal", "elefant", "elephant", constants.StrictnessNormal, MatchClose},
+		{"too many typos, normal", "elifant", "elephant", constants.StrictnessNormal, MatchWrong},
+		{"two typos, normal", "grdan", "garden", constants.StrictnessNormal, MatchWrong},
+		{"two typos, lenient", "grdan", "garden", constants.StrictnessLenient, MatchClose},
+		{"two typos in short word, lenient", "hiuse", "horse", constants.StrictnessLenient, MatchWrong},
+		{"short word, normal", "cat", "car", constants.StrictnessNormal, MatchWrong},
+		{"short word, lenient", "cat", "car", constants.StrictnessLenient, MatchClose},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matcher.Match(tt.answer, tt.expected, tt.strictness); got != tt.want {
+				t.Errorf("Match(%q, %q, %s) = %v, want %v", tt.answer, tt.expected, tt.strictness, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnswerMatcherMatchAny(t *testing.T) {
+	matcher := NewAnswerMatcher()
+
+	result, matched := matcher.MatchAny("здание", []string{"дом", "здание"}, constants.StrictnessNormal)
+	if result != MatchExact || matched != "здание" {
+		t.Errorf("got %v %q, want exact match with %q", result, matched, "здание")
+	}
+
+	result, matched = matcher.MatchAny("самолёт", []string{"лодка"}, constants.StrictnessLenient)
+	if result != MatchWrong || matched != "" {
+		t.Errorf("got %v %q, want no match", result, matched)
+	}
+}
+
+func TestAnswerMatcherDistance(t *testing.T) {
+	matcher := NewAnswerMatcher()
+
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"house", "house", 0},
+		{"house", "horse", 1},
+		{"house", "hosue", 1},
+		{"kitten", "sitting", 3},
+		{"ca", "abc", 3},
+		{"дом", "том", 1},
+		{"ёж", "еж", 1},
+	}
+
+	for _, tt := range tests {
+		if got := matcher.Distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("Distance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := matcher.Distance(tt.b, tt.a); got != tt.want {
+			t.Errorf("Distance(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestAllowedTypos(t *testing.T) {
+	tests := []struct {
+		length     int
+		strictness string
+		want       int
+	}{
+		{3, constants.StrictnessNormal, 0},
+		{4, constants.StrictnessNormal, 1},
+		{7, constants.StrictnessNormal, 1},
+		{8, constants.StrictnessNormal, 2},
+		{20, constants.StrictnessNormal, 2},
+		{2, constants.StrictnessLenient, 0},
+		{3, constants.StrictnessLenient, 1},
+		{5, constants.StrictnessLenient, 1},
+		{6, constants.StrictnessLenient, 2},
+		{9, constants.StrictnessLenient, 2},
+		{10, constants.StrictnessLenient, 3},
+	}
+
+	for _, tt := range tests {
+		if got := allowedTypos(tt.length, tt.strictness); got != tt.want {
+			t.Errorf("allowedTypos(%d, %s) = %d, want %d", tt.length, tt.strictness, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"ivanSaichkin/language-bot/internal/domain"
+	"ivanSaichkin/language-bot/internal/repository"
+)
+
+type deckService struct {
+	deckRepo repository.DeckRepository
+	wordRepo repository.WordRepository
+	userRepo repository.UserRepository
+}
+
+func NewDeckService(
+	deckRepo repository.DeckRepository,
+	wordRepo repository.WordRepository,
+	userRepo repository.UserRepository,
+) DeckService {
+	return &deckService{
+		deckRepo: deckRepo,
+		wordRepo: wordRepo,
+		userRepo: userRepo,
+	}
+}
+
+func (s *deckService) CreateDeck(ctx context.Context, userID int64, name string) (*domain.Deck, error) {
+	if err := validateDeckName(name); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.FindDeck(ctx, userID, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("deck already exists: %s", existing.Name)
+	}
+
+	deck := domain.NewDeck(userID, name)
+	if err := s.deckRepo.Create(ctx, deck); err != nil {
+		return nil, fmt.Errorf("failed to create deck: %w", err)
+	}
+
+	log.Printf("📂 Created deck %q for user %d", deck.Name, userID)
+	return deck, nil
+}
+
+func (s *deckService) GetUserDecks(ctx context.Context, userID int64) ([]*domain.Deck, error) {
+	decks, err := s.deckRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user decks: %w", err)
+	}
+
+	return decks, nil
+}
+
+// GetDeck возвращает колоду пользователя или nil, если её нет или она принадлежит другому пользователю
+func (s *deckService) GetDeck(ctx context.Context, userID int64, deckID int) (*domain.Deck, error) {
+	deck, err := s.deckRepo.GetByID(ctx, userID, deckID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deck: %w", err)
+	}
+
+	return deck, nil
+}
+
+// FindDeck ищет колоду пользователя по имени без учёта регистра; nil, если не найдена
+func (s *deckService) FindDeck(ctx context.Context, userID int64, name string) (*domain.Deck, error) {
+	decks, err := s.GetUserDecks(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, deck := range decks {
+		if deck.HasName(name) {
+			return deck, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *deckService) RenameDeck(ctx context.Context, deck *domain.Deck, name string) error {
+	if err := validateDeckName(name); err != nil {
+		return err
+	}
+
+	existing, err := s.FindDeck(ctx, deck.UserID, name)
+	if err != nil {
+		return err
+	}
+	if existing != nil && existing.ID != deck.ID {
+		return fmt.Errorf("deck already exists: %s", existing.Name)
+	}
+
+	deck.Rename(name)
+	if err := s.deckRepo.Update(ctx, deck.UserID, deck); err != nil {
+		return fmt.Errorf("failed to rename deck: %w", err)
+	}
+
+	return nil
+}
+
+func (s *deckService) DeleteDeck(ctx context.Context, deck *domain.Deck) error {
+	if err := s.deckRepo.Delete(ctx, deck.UserID, deck.ID); err != nil {
+		return fmt.Errorf("failed to delete deck: %w", err)
+	}
+
+	log.Printf("🗑 Deleted deck %q for user %d", deck.Name, deck.UserID)
+	return nil
+}
+
+// SetCurrentDeck выбирает колоду для новых слов; deckID = 0 - без колоды
+func (s *deckService) SetCurrentDeck(ctx context.Context, userID int64, deckID int) error {
+	if deckID != 0 {
+		deck, err := s.GetDeck(ctx, userID, deckID)
+		if err != nil {
+			return err
+		}
+		if deck == nil {
+			return fmt.Errorf("deck not found: %d", deckID)
+		}
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user for deck update: %w", err)
+	}
+
+	if user == nil {
+		return fmt.Errorf("user not found: %d", userID)
+	}
+
+	user.SetCurrentDeck(deckID)
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update current deck: %w", err)
+	}
+
+	return nil
+}
+
+// MoveWord переносит слово пользователя в колоду; nil, если такого слова нет
+func (s *deckService) MoveWord(ctx context.Context, userID int64, original string, deckID int) (*domain.Word, error) {
+	words, err := s.wordRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user words: %w", err)
+	}
+
+	target := domain.NormalizeText(original)
+	for _, word := range words {
+		if domain.NormalizeText(word.Original) != target {
+			continue
+		}
+
+		if err := s.wordRepo.MoveToDeck(ctx, userID, word.ID, deckID); err != nil {
+			return nil, fmt.Errorf("failed to move word: %w", err)
+		}
+
+		word.DeckID = deckID
+		return word, nil
+	}
+
+	return nil, nil
+}
+
+func validateDeckName(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("deck name cannot be empty")
+	}
+
+	if len([]rune(name)) > domain.MaxDeckNameLength {
+		return fmt.Errorf("deck name too long")
+	}
+
+	return nil
+}
@@ -1,13 +1,57 @@
 package service
 
-import "time"
+import (
+	"time"
+
+	"ivanSaichkin/language-bot/internal/domain"
+)
 
 type ReviewAnswerResult struct {
-	IsCorrect       bool
-	CorrectAnswer   string
-	NextInterval    time.Duration
-	OriginalWord    string
-	SessionProgress *SessionProgress
+	WordID             int
+	IsCorrect          bool
+	Grade              domain.RecallGrade
+	Quality            int
+	Match              MatchResult
+	MatchedTranslation string
+	CorrectAnswer      string
+	NextInterval       time.Duration
+	ResponseTime       time.Duration
+	OriginalWord       string
+	SessionProgress    *SessionProgress
+}
+
+type QuizAnswerResult struct {
+	IsCorrect          bool
+	Match              MatchResult
+	MatchedTranslation string
+	Prompt             string
+	CorrectAnswer      string
+	IsComplete         bool
+}
+
+type SprintAnswerResult struct {
+	IsCorrect   bool
+	Points      int
+	Original    string
+	Translation string
+	IsMatch     bool
+	IsComplete  bool
+}
+
+type SprintResult struct {
+	Score     int
+	Best      int
+	IsNewBest bool
+	Correct   int
+	Answered  int
+	MaxCombo  int
+	Accuracy  float64
+}
+
+type MatchPairResult struct {
+	IsCorrect  bool
+	Original   string
+	IsComplete bool
 }
 
 type SessionProgress struct {
@@ -26,6 +70,141 @@ type WordProgress struct {
 	TodayReviewed int
 }
 
+// BulkAddResult - итог добавления списка слов. Merged - слова словаря,
+// к которым добавлены переводы новых слов; Duplicates - пропущенные новые слова.
+type BulkAddResult struct {
+	Added      []*domain.Word
+	Merged     []*domain.Word
+	Duplicates []*domain.Word
+	Invalid    []*domain.Word
+}
+
+// DuplicateMatch - новое слово и слово словаря, которое его повторяет;
+// Exact - оригиналы совпадают, иначе отличаются опечаткой при общем переводе
+type DuplicateMatch struct {
+	Word     *domain.Word
+	Existing *domain.Word
+	Exact    bool
+}
+
+// ImportRow - строка таблицы; Deck - колода из колонки тегов, пусто - текущая колода пользователя
+type ImportRow struct {
+	Line int
+	Word *domain.Word
+	Deck string
+}
+
+// ImportRowError - строка файла, которая не будет импортирована; Reason - одна из ImportError*
+type ImportRowError struct {
+	Line   int
+	Reason string
+}
+
+// ImportPreview - разобранный файл, который ждёт подтверждения импорта.
+// Duplicates - сколько строк повторяют слова, которые уже есть в словаре,
+// Decks - колоды из колонки тегов в порядке появления.
+type ImportPreview struct {
+	Format     string
+	Columns    []string
+	HasHeader  bool
+	Rows       []*ImportRow
+	Errors     []ImportRowError
+	Duplicates int
+	Decks      []string
+}
+
+type ImportResult struct {
+	Added      int
+	Merged     int
+	Duplicates int
+	Errors     []ImportRowError
+}
+
+// AnkiPackage - разобранный .apkg, который ждёт подтверждения сопоставления полей
+type AnkiPackage struct {
+	Models []*AnkiModel
+	Notes  []*AnkiNote
+}
+
+type AnkiModel struct {
+	ID      int64
+	Name    string
+	Fields  []string
+	Mapping AnkiFieldMapping
+}
+
+// AnkiFieldMapping - номера полей заметки; -1 - поле не используется.
+// Пользователь меняет сопоставление до импорта, PreviewAnki читает его из модели.
+type AnkiFieldMapping struct {
+	Original    int
+	Translation int
+	Example     int
+}
+
+type AnkiNote struct {
+	Number  int
+	ModelID int64
+	Fields  []string
+	Card    *AnkiCard // nil - карточка новая или отложена
+}
+
+type AnkiCard struct {
+	IntervalDays float64
+	Ease         float64
+	Due          time.Time
+	Reviews      int
+	Lapses       int
+}
+
+type AnkiImportOptions struct {
+	KeepSchedule bool // перенести интервалы и даты повторений из Anki
+}
+
+type ExportFile struct {
+	Name      string
+	Data      []byte
+	WordCount int
+}
+
+// WordListQuery - параметры постраничного списка слов
+type WordListQuery struct {
+	Filter       domain.WordFilter
+	Status       string // constants.WordStatus*, пустая строка - все
+	PartOfSpeech string // пустая строка - любая часть речи
+	Sort         string // constants.WordSort*
+	Page         int    // с нуля
+	PageSize     int
+}
+
+type WordListPage struct {
+	Words     []*domain.Word
+	Total     int
+	Due       int // слов к повторению среди подходящих под Filter
+	Page      int
+	Pages     int
+	Languages []string // языки, на которых у пользователя есть слова
+}
+
+// WordDetails - слово с дополнительными карточками и журналом ответов, новые записи первыми
+type WordDetails struct {
+	Word    *domain.Word
+	Cards   []*domain.Card
+	History []*domain.ReviewLog
+}
+
+// WordMatch - результат поиска; Fuzzy отмечает слова, найденные с опечаткой в запросе
+type WordMatch struct {
+	Word  *domain.Word
+	Fuzzy bool
+}
+
+type LanguageProgress struct {
+	Language     string
+	TotalWords   int
+	LearnedWords int
+	DueWords     int
+}
+
 type UserStatsRanking struct {
 	UserID       int64
 	Username     string
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"ivanSaichkin/language-bot/internal/domain"
+	"ivanSaichkin/language-bot/internal/repository"
+)
+
+// Форматы выгрузки
+const (
+	ExportFormatCSV  = "csv"
+	ExportFormatJSON = "json"
+	ExportFormatAnki = "anki"
+)
+
+// exportService выгружает словарь пользователя вместе с расписанием повторений.
+// CSV читается обратно импортом таблиц, текст для Anki - импортом «Текст, разделённый табуляцией».
+type exportService struct {
+	wordService WordService
+	statsRepo   repository.StatsRepository
+	deckRepo    repository.DeckRepository
+}
+
+func NewExportService(
+	wordService WordService,
+	statsRepo repository.StatsRepository,
+	deckRepo repository.DeckRepository,
+) ExportService {
+	return &exportService{
+		wordService: wordService,
+		statsRepo:   statsRepo,
+		deckRepo:    deckRepo,
+	}
+}
+
+// exportWord - слово в JSON-выгрузке; формат не зависит от внутренних полей domain.Word
+type exportWord struct {
+	Original       string     `json:"original"`
+	Translations   []string   `json:"translations"`
+	Example        string     `json:"example,omitempty"`
+	Language       string     `json:"language"`
+	PartOfSpeech   string     `json:"part_of_speech"`
+	Deck           string     `json:"deck,omitempty"`
+	Difficulty     float64    `json:"difficulty"`
+	IntervalDays   float64    `json:"interval_days"`
+	NextReview     time.Time  `json:"next_review"`
+	LastReviewedAt *time.Time `json:"last_reviewed_at,omitempty"`
+	ReviewCount    int        `json:"review_count"`
+	CorrectAnswers int        `json:"correct_answers"`
+	Stability      float64    `json:"stability,omitempty"`
+	FSRSDifficulty float64    `json:"fsrs_difficulty,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type exportDocument struct {
+	ExportedAt time.Time         `json:"exported_at"`
+	Stats      *domain.UserStats `json:"stats,omitempty"`
+	Words      []exportWord      `json:"words"`
+}
+
+var ankiFieldReplacer = strings.NewReplacer("\t", " ", "\r", " ", "\n", " ")
+
+var exportCSVHeader = []string{
+	"original", "translation", "example", "language", "part_of_speech", "deck",
+	"difficulty", "interval_days", "next_review", "last_reviewed_at", "review_count", "correct_answers", "created_at",
+}
+
+func (s *exportService) Export(ctx context.Context, userID int64, format string) (*ExportFile, error) {
+	words, err := s.wordService.GetUserWords(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	decks, err := s.deckNames(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	switch format {
+	case ExportFormatCSV:
+		data, err = exportCSV(words, decks)
+	case ExportFormatJSON:
+		stats, statsErr := s.statsRepo.GetByUserID(ctx, userID)
+		if statsErr != nil {
+			return nil, fmt.Errorf("failed to get stats for export: %w", statsErr)
+		}
+		data, err = exportJSON(words, decks, stats)
+	case ExportFormatAnki:
+		data = exportAnki(words, decks)
+	default:
+		return nil, fmt.Errorf("unknown export format: %s", format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("📤 Exported %d words for user %d as %s", len(words), userID, format)
+
+	return &ExportFile{
+		Name:      exportFileName(format),
+		Data:      data,
+		WordCount: len(words),
+	}, nil
+}
+
+func (s *exportService) deckNames(ctx context.Context, userID int64) (map[int]string, error) {
+	decks, err := s.deckRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get decks for export: %w", err)
+	}
+
+	names := make(map[int]string, len(decks))
+	for _, deck := range decks {
+		names[deck.ID] = deck.Name
+	}
+
+	return names, nil
+}
+
+func exportCSV(words []*domain.Word, decks map[int]string) ([]byte, error) {
+	var buf bytes.Buffer
+	// BOM нужен, чтобы Excel открыл файл в UTF-8
+	buf.WriteString("\xef\xbb\xbf")
+
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(exportCSVHeader); err != nil {
+		return nil, fmt.Errorf("failed to write csv: %w", err)
+	}
+
+	for _, word := range words {
+		record := []string{
+			word.Original,
+			strings.Join(word.AcceptedTranslations(), ", "),
+			word.Example,
+			word.Language,
+			word.PartOfSpeech,
+			decks[word.DeckID],
+			strconv.FormatFloat(word.Difficulty, 'f', 2, 64),
+			strconv.FormatFloat(word.IntervalDays, 'f', 2, 64),
+			word.NextReview.Format(time.RFC3339),
+			formatExportTime(word.LastReviewedAt),
+			strconv.Itoa(word.ReviewCount),
+			strconv.Itoa(word.CorrectAnswers),
+			word.CreatedAt.Format(time.RFC3339),
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, fmt.Errorf("failed to write csv: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write csv: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func exportJSON(words []*domain.Word, decks map[int]string, stats *domain.UserStats) ([]byte, error) {
+	document := exportDocument{
+		ExportedAt: time.Now(),
+		Stats:      stats,
+		Words:      make([]exportWord, 0, len(words)),
+	}
+
+	for _, word := range words {
+		exported := exportWord{
+			Original:       word.Original,
+			Translations:   word.AcceptedTranslations(),
+			Example:        word.Example,
+			Language:       word.Language,
+			PartOfSpeech:   word.PartOfSpeech,
+			Deck:           decks[word.DeckID],
+			Difficulty:     word.Difficulty,
+			IntervalDays:   word.IntervalDays,
+			NextReview:     word.NextReview,
+			ReviewCount:    word.ReviewCount,
+			CorrectAnswers: word.CorrectAnswers,
+			Stability:      word.Stability,
+			FSRSDifficulty: word.FSRSDifficulty,
+			CreatedAt:      word.CreatedAt,
+		}
+		if !word.LastReviewedAt.IsZero() {
+			lastReviewed := word.LastReviewedAt
+			exported.LastReviewedAt = &lastReviewed
+		}
+
+		document.Words = append(document.Words, exported)
+	}
+
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode json: %w", err)
+	}
+
+	return data, nil
+}
+
+// exportAnki пишет текст с табуляцией и заголовками, которые Anki понимает при импорте:
+// лицевая сторона, оборот, пример и теги (язык и колода)
+func exportAnki(words []*domain.Word, decks map[int]string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("#separator:tab\n#html:false\n#columns:Front\tBack\tExample\tTags\n")
+
+	for _, word := range words {
+		tags := []string{word.Language}
+		if deck := decks[word.DeckID]; deck != "" {
+			tags = append(tags, strings.Join(strings.Fields(deck), "_"))
+		}
+
+		fields := []string{
+			word.Original,
+			strings.Join(word.AcceptedTranslations(), ", "),
+			word.Example,
+			strings.Join(tags, " "),
+		}
+		for i, field := range fields {
+			fields[i] = ankiFieldReplacer.Replace(field)
+		}
+
+		buf.WriteString(strings.Join(fields, "\t"))
+		buf.WriteString("\n")
+	}
+
+	return buf.Bytes()
+}
+
+func exportFileName(format string) string {
+	extension := format
+	if format == ExportFormatAnki {
+		extension = "txt"
+	}
+
+	return fmt.Sprintf("words-%s.%s", time.Now().Format("2006-01-02"), extension)
+}
+
+func formatExportTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC3339)
+}
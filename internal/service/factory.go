@@ -7,7 +7,13 @@ import (
 type ServiceContainer struct {
 	UserService       UserService
 	WordService       WordService
+	DeckService       DeckService
+	ImportService     ImportService
+	ExportService     ExportService
 	ReviewService     ReviewService
+	QuizService       QuizService
+	SprintService     SprintService
+	MatchService      MatchService
 	StatsService      StatsService
 	SessionService    SessionService
 	RepetitionService SpacedRepetitionService
@@ -18,21 +24,37 @@ func NewServiceContainer(
 	wordRepo repository.WordRepository,
 	statsRepo repository.StatsRepository,
 	sessionRepo repository.SessionRepository,
+	reviewLogRepo repository.ReviewLogRepository,
+	cardRepo repository.CardRepository,
+	deckRepo repository.DeckRepository,
 ) *ServiceContainer {
 	// Создаем сервис повторений
 	repetitionService := NewSpacedRepetitionService()
 
 	// Создаем основные сервисы
 	userService := NewUserService(userRepo, wordRepo, statsRepo)
-	wordService := NewWordService(wordRepo, statsRepo)
-	statsService := NewStatsService(userRepo, wordRepo, statsRepo)
-	reviewService := NewReviewService(wordRepo, statsRepo, repetitionService)
+	wordService := NewWordService(wordRepo, statsRepo, userRepo, reviewLogRepo, cardRepo)
+	deckService := NewDeckService(deckRepo, wordRepo, userRepo)
+	importService := NewImportService(wordService, deckService, userRepo)
+	exportService := NewExportService(wordService, statsRepo, deckRepo)
+	statsService := NewStatsService(userRepo, wordRepo, statsRepo, reviewLogRepo)
+	matcher := NewAnswerMatcher()
+	reviewService := NewReviewService(wordRepo, cardRepo, statsRepo, userRepo, reviewLogRepo, repetitionService, matcher)
+	quizService := NewQuizService(wordRepo, userRepo, matcher)
+	sprintService := NewSprintService(wordRepo, statsRepo, reviewLogRepo)
+	matchService := NewMatchService(wordRepo, reviewLogRepo)
 	sessionService := NewSessionService(sessionRepo)
 
 	return &ServiceContainer{
 		UserService:       userService,
 		WordService:       wordService,
+		DeckService:       deckService,
+		ImportService:     importService,
+		ExportService:     exportService,
 		ReviewService:     reviewService,
+		QuizService:       quizService,
+		SprintService:     sprintService,
+		MatchService:      matchService,
 		StatsService:      statsService,
 		SessionService:    sessionService,
 		RepetitionService: repetitionService,
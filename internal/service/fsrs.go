@@ -0,0 +1,177 @@
+package service
+
+import (
+	"fmt"
+	"ivanSaichkin/language-bot/internal/domain"
+	"math"
+	"time"
+)
+
+// Параметры FSRS-4.5 по умолчанию
+var defaultFSRSWeights = [17]float64{
+	0.4872, 1.4003, 3.7145, 13.8206,
+	5.1618, 1.2298, 0.8975, 0.031,
+	1.6474, 0.1367, 1.0461, 2.1072,
+	0.0793, 0.3246, 1.587, 0.2272,
+	2.8755,
+}
+
+const (
+	fsrsDecay  = -0.5
+	fsrsFactor = 19.0 / 81.0
+
+	fsrsMaxIntervalDays = 36500
+)
+
+// fsrsService реализует планировщик FSRS: интервал подбирается так,
+// чтобы вероятность вспомнить слово к следующему повторению была равна desiredRetention.
+type fsrsService struct {
+	weights          [17]float64
+	desiredRetention float64
+	minInterval      time.Duration
+}
+
+func NewFSRSService(desiredRetention float64) SpacedRepetitionService {
+	if desiredRetention <= 0 || desiredRetention >= 1 {
+		desiredRetention = 0.9
+	}
+
+	return &fsrsService{
+		weights:          defaultFSRSWeights,
+		desiredRetention: desiredRetention,
+		minInterval:      time.Hour * 24,
+	}
+}
+
+func (s *fsrsService) CalculateNextReview(word *domain.Word, isCorrect bool) (*domain.ReviewResult, error) {
+	grade := domain.GradeAgain
+	if isCorrect {
+		grade = domain.GradeGood
+	}
+
+	return s.CalculateNextReviewWithQuality(word, grade.Quality())
+}
+
+func (s *fsrsService) CalculateNextReviewWithQuality(word *domain.Word, quality int) (*domain.ReviewResult, error) {
+	if quality < 0 || quality > 5 {
+		return nil, fmt.Errorf("quality must be between 0 and 5, got %d", quality)
+	}
+
+	rating := float64(domain.GradeFromQuality(quality))
+
+	var stability, difficulty, retrievability float64
+
+	if word.Stability <= 0 {
+		// Первое повторение в FSRS: начальное состояние зависит только от оценки
+		stability = s.initialStability(rating)
+		difficulty = s.initialDifficulty(rating)
+		retrievability = 1
+	} else {
+		retrievability = s.retrievability(s.elapsedDays(word), word.Stability)
+		difficulty = s.nextDifficulty(word.FSRSDifficulty, rating)
+
+		if rating == float64(domain.GradeAgain) {
+			stability = s.forgetStability(word.FSRSDifficulty, word.Stability, retrievability)
+		} else {
+			stability = s.recallStability(word.FSRSDifficulty, word.Stability, retrievability, rating)
+		}
+	}
+
+	intervalDays := s.nextIntervalDays(stability)
+
+	return &domain.ReviewResult{
+		WordID:            word.ID,
+		IsCorrect:         quality >= 3,
+		Quality:           quality,
+		NextInterval:      time.Duration(intervalDays * float64(s.minInterval)),
+		NewDifficulty:     word.Difficulty,
+		NewStability:      stability,
+		NewFSRSDifficulty: difficulty,
+		Retrievability:    retrievability,
+	}, nil
+}
+
+func (s *fsrsService) GetWordsForReview(words []*domain.Word) []*domain.Word {
+	var dueWords []*domain.Word
+
+	for _, word := range words {
+		if word.IsDueForReview() {
+			dueWords = append(dueWords, word)
+		}
+	}
+	return dueWords
+}
+
+// CalculateEaseFactor оставляет коэффициент SM-2 без изменений:
+// FSRS хранит сложность отдельно, а ease нужен при возврате на SM-2.
+func (s *fsrsService) CalculateEaseFactor(word *domain.Word, quality int) float64 {
+	return word.Difficulty
+}
+
+func (s *fsrsService) elapsedDays(word *domain.Word) float64 {
+	if word.LastReviewedAt.IsZero() {
+		return word.IntervalDays
+	}
+
+	return math.Max(0, time.Since(word.LastReviewedAt).Hours()/24)
+}
+
+func (s *fsrsService) retrievability(elapsedDays, stability float64) float64 {
+	return math.Pow(1+fsrsFactor*elapsedDays/stability, fsrsDecay)
+}
+
+func (s *fsrsService) nextIntervalDays(stability float64) float64 {
+	interval := stability / fsrsFactor * (math.Pow(s.desiredRetention, 1/fsrsDecay) - 1)
+	interval = math.Round(interval)
+
+	return math.Min(math.Max(interval, 1), fsrsMaxIntervalDays)
+}
+
+func (s *fsrsService) initialStability(rating float64) float64 {
+	return math.Max(s.weights[int(rating)-1], 0.1)
+}
+
+func (s *fsrsService) initialDifficulty(rating float64) float64 {
+	return clampDifficulty(s.weights[4] - (rating-3)*s.weights[5])
+}
+
+func (s *fsrsService) nextDifficulty(difficulty, rating float64) float64 {
+	next := difficulty - s.weights[6]*(rating-3)
+	// Возврат к среднему, чтобы сложность не залипала на краях шкалы
+	next = s.weights[7]*s.initialDifficulty(3) + (1-s.weights[7])*next
+
+	return clampDifficulty(next)
+}
+
+func (s *fsrsService) recallStability(difficulty, stability, retrievability, rating float64) float64 {
+	hardPenalty := 1.0
+	if rating == float64(domain.GradeHard) {
+		hardPenalty = s.weights[15]
+	}
+
+	easyBonus := 1.0
+	if rating == float64(domain.GradeEasy) {
+		easyBonus = s.weights[16]
+	}
+
+	return stability * (1 + math.Exp(s.weights[8])*
+		(11-difficulty)*
+		math.Pow(stability, -s.weights[9])*
+		(math.Exp((1-retrievability)*s.weights[10])-1)*
+		hardPenalty*
+		easyBonus)
+}
+
+func (s *fsrsService) forgetStability(difficulty, stability, retrievability float64) float64 {
+	next := s.weights[11] *
+		math.Pow(difficulty, -s.weights[12]) *
+		(math.Pow(stability+1, s.weights[13]) - 1) *
+		math.Exp((1-retrievability)*s.weights[14])
+
+	// Стабильность после забывания не может превышать прежнюю
+	return math.Min(math.Max(next, 0.1), stability)
+}
+
+func clampDifficulty(difficulty float64) float64 {
+	return math.Min(math.Max(difficulty, 1), 10)
+}
@@ -0,0 +1,418 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"ivanSaichkin/language-bot/internal/constants"
+	"ivanSaichkin/language-bot/internal/domain"
+	"ivanSaichkin/language-bot/internal/repository"
+)
+
+const (
+	MaxImportFileSize = 1 << 20
+	MaxImportRows     = 1000
+)
+
+// Колонки таблицы для импорта
+const (
+	ImportColumnOriginal     = "original"
+	ImportColumnTranslation  = "translation"
+	ImportColumnExample      = "example"
+	ImportColumnLanguage     = "language"
+	ImportColumnPartOfSpeech = "part_of_speech"
+	ImportColumnTags         = "tags"
+)
+
+// Причины, по которым строка не импортируется
+const (
+	ImportErrorMissingFields = "missing_fields"
+	ImportErrorLanguage      = "language"
+	ImportErrorPartOfSpeech  = "part_of_speech"
+	ImportErrorDeck          = "deck"
+	ImportErrorInvalid       = "invalid"
+	ImportErrorSave          = "save"
+)
+
+// Без заголовка колонки читаются в этом порядке
+var defaultImportColumns = []string{
+	ImportColumnOriginal,
+	ImportColumnTranslation,
+	ImportColumnExample,
+	ImportColumnLanguage,
+	ImportColumnPartOfSpeech,
+	ImportColumnTags,
+}
+
+var importColumnAliases = map[string]string{
+	"original":       ImportColumnOriginal,
+	"word":           ImportColumnOriginal,
+	"term":           ImportColumnOriginal,
+	"front":          ImportColumnOriginal,
+	"слово":          ImportColumnOriginal,
+	"оригинал":       ImportColumnOriginal,
+	"translation":    ImportColumnTranslation,
+	"meaning":        ImportColumnTranslation,
+	"back":           ImportColumnTranslation,
+	"перевод":        ImportColumnTranslation,
+	"example":        ImportColumnExample,
+	"sentence":       ImportColumnExample,
+	"пример":         ImportColumnExample,
+	"language":       ImportColumnLanguage,
+	"lang":           ImportColumnLanguage,
+	"язык":           ImportColumnLanguage,
+	"part_of_speech": ImportColumnPartOfSpeech,
+	"part of speech": ImportColumnPartOfSpeech,
+	"pos":            ImportColumnPartOfSpeech,
+	"часть речи":     ImportColumnPartOfSpeech,
+	"tags":           ImportColumnTags,
+	"tag":            ImportColumnTags,
+	"теги":           ImportColumnTags,
+	"deck":           ImportColumnTags,
+	"колода":         ImportColumnTags,
+}
+
+// importService разбирает таблицы со словами и добавляет их через WordService,
+// чтобы импорт проходил те же проверки, что и ручное добавление. Первый тег строки -
+// колода слова: её находит или создаёт DeckService.
+type importService struct {
+	wordService WordService
+	deckService DeckService
+	userRepo    repository.UserRepository
+}
+
+func NewImportService(wordService WordService, deckService DeckService, userRepo repository.UserRepository) ImportService {
+	return &importService{
+		wordService: wordService,
+		deckService: deckService,
+		userRepo:    userRepo,
+	}
+}
+
+// ParseTable разбирает CSV или TSV в UTF-8. Разделитель определяется по первой строке,
+// заголовок - по названиям колонок; язык по умолчанию - изучаемый язык пользователя.
+func (s *importService) ParseTable(ctx context.Context, userID int64, data []byte) (*ImportPreview, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(data) {
+		return nil, fmt.Errorf("file is not valid UTF-8")
+	}
+
+	delimiter, err := detectDelimiter(data)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+
+	language, err := s.defaultLanguage(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &ImportPreview{Format: "CSV"}
+	if delimiter == '\t' {
+		preview.Format = "TSV"
+	}
+
+	var columns []string
+	width := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read table: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		if isEmptyRecord(record) {
+			continue
+		}
+
+		if columns == nil {
+			if header, ok := parseImportHeader(record); ok {
+				columns, preview.HasHeader = header, true
+				continue
+			}
+			columns = defaultImportColumns
+		}
+
+		width = max(width, len(record))
+		if len(preview.Rows)+len(preview.Errors) >= MaxImportRows {
+			return nil, fmt.Errorf("too many rows, max %d", MaxImportRows)
+		}
+
+		row, reason := buildImportRow(userID, language, columns, record)
+		if reason != "" {
+			preview.Errors = append(preview.Errors, ImportRowError{Line: line, Reason: reason})
+			continue
+		}
+
+		row.Line = line
+		preview.Rows = append(preview.Rows, row)
+		if row.Deck != "" && !containsDeckName(preview.Decks, row.Deck) {
+			preview.Decks = append(preview.Decks, row.Deck)
+		}
+	}
+
+	preview.Columns = usedImportColumns(columns, preview.HasHeader, width)
+
+	if err := s.countDuplicates(ctx, userID, preview); err != nil {
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+// Import добавляет разобранные строки порциями по MaxBulkWords: ошибка сохранения
+// одной порции не отменяет уже добавленные слова. Повторы обрабатываются по onDuplicate.
+func (s *importService) Import(ctx context.Context, userID int64, preview *ImportPreview, onDuplicate string) (*ImportResult, error) {
+	if preview == nil {
+		return nil, fmt.Errorf("nothing to import")
+	}
+
+	if err := s.assignDecks(ctx, userID, preview); err != nil {
+		return nil, err
+	}
+
+	result := &ImportResult{Errors: append([]ImportRowError(nil), preview.Errors...)}
+
+	for start := 0; start < len(preview.Rows); start += MaxBulkWords {
+		rows := preview.Rows[start:min(start+MaxBulkWords, len(preview.Rows))]
+
+		words := make([]*domain.Word, len(rows))
+		lines := make(map[*domain.Word]int, len(rows))
+		for i, row := range rows {
+			words[i] = row.Word
+			lines[row.Word] = row.Line
+		}
+
+		added, err := s.wordService.AddWords(ctx, userID, words, onDuplicate)
+		if err != nil {
+			log.Printf("⚠️ Failed to import batch for user %d: %v", userID, err)
+			for _, row := range rows {
+				result.Errors = append(result.Errors, ImportRowError{Line: row.Line, Reason: ImportErrorSave})
+			}
+			continue
+		}
+
+		result.Added += len(added.Added)
+		result.Merged += len(added.Merged)
+		result.Duplicates += len(added.Duplicates)
+		for _, word := range added.Invalid {
+			result.Errors = append(result.Errors, ImportRowError{Line: lines[word], Reason: ImportErrorInvalid})
+		}
+	}
+
+	sort.SliceStable(result.Errors, func(i, j int) bool { return result.Errors[i].Line < result.Errors[j].Line })
+
+	log.Printf("📥 Imported %d words for user %d, merged %d, %d duplicates, %d errors",
+		result.Added, userID, result.Merged, result.Duplicates, len(result.Errors))
+
+	return result, nil
+}
+
+// countDuplicates отмечает в предпросмотре, сколько строк повторяют слова словаря
+func (s *importService) countDuplicates(ctx context.Context, userID int64, preview *ImportPreview) error {
+	words := make([]*domain.Word, len(preview.Rows))
+	for i, row := range preview.Rows {
+		words[i] = row.Word
+	}
+
+	matches, err := s.wordService.FindDuplicates(ctx, userID, words)
+	if err != nil {
+		return fmt.Errorf("failed to find duplicates: %w", err)
+	}
+
+	preview.Duplicates = len(matches)
+	return nil
+}
+
+// assignDecks находит или создаёт колоды из тегов и переносит в них слова строк
+func (s *importService) assignDecks(ctx context.Context, userID int64, preview *ImportPreview) error {
+	if len(preview.Decks) == 0 {
+		return nil
+	}
+
+	deckIDs := make(map[string]int, len(preview.Decks))
+	for _, name := range preview.Decks {
+		deck, err := s.deckService.FindDeck(ctx, userID, name)
+		if err != nil {
+			return fmt.Errorf("failed to find deck %q: %w", name, err)
+		}
+		if deck == nil {
+			if deck, err = s.deckService.CreateDeck(ctx, userID, name); err != nil {
+				return fmt.Errorf("failed to create deck %q: %w", name, err)
+			}
+		}
+		deckIDs[strings.ToLower(name)] = deck.ID
+	}
+
+	for _, row := range preview.Rows {
+		if row.Deck != "" {
+			row.Word.DeckID = deckIDs[strings.ToLower(row.Deck)]
+		}
+	}
+
+	return nil
+}
+
+func (s *importService) defaultLanguage(ctx context.Context, userID int64) (string, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get user: %w", err)
+	}
+
+	if user == nil || user.StudyLanguage == "" {
+		return constants.LanguageEnglish, nil
+	}
+
+	return user.StudyLanguage, nil
+}
+
+// detectDelimiter выбирает самый частый из tab, ; и , в первой непустой строке
+func detectDelimiter(data []byte) (rune, error) {
+	var firstLine string
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) != "" {
+			firstLine = line
+			break
+		}
+	}
+
+	delimiter, best := rune(0), 0
+	for _, candidate := range []rune{'\t', ';', ','} {
+		if count := strings.Count(firstLine, string(candidate)); count > best {
+			delimiter, best = candidate, count
+		}
+	}
+
+	if delimiter == 0 {
+		return 0, fmt.Errorf("failed to detect delimiter")
+	}
+
+	return delimiter, nil
+}
+
+// parseImportHeader считает строку заголовком, если в ней есть колонки слова и перевода
+func parseImportHeader(record []string) ([]string, bool) {
+	columns := make([]string, len(record))
+	found := make(map[string]bool)
+	for i, cell := range record {
+		column := importColumnAliases[strings.ToLower(strings.TrimSpace(cell))]
+		columns[i] = column
+		found[column] = true
+	}
+
+	return columns, found[ImportColumnOriginal] && found[ImportColumnTranslation]
+}
+
+// buildImportRow собирает слово из строки таблицы или возвращает причину ошибки.
+// Теги разделяются запятой или точкой с запятой, колодой слова становится первый.
+func buildImportRow(userID int64, language string, columns []string, record []string) (*ImportRow, string) {
+	values := make(map[string]string)
+	for i, cell := range record {
+		if i < len(columns) && columns[i] != "" {
+			values[columns[i]] = strings.TrimSpace(cell)
+		}
+	}
+
+	original := values[ImportColumnOriginal]
+	translations := splitImportList(values[ImportColumnTranslation])
+	if original == "" || len(translations) == 0 {
+		return nil, ImportErrorMissingFields
+	}
+
+	if code := values[ImportColumnLanguage]; code != "" {
+		found, ok := domain.FindLanguage(code)
+		if !ok {
+			return nil, ImportErrorLanguage
+		}
+		language = found.Code
+	}
+
+	word := domain.NewWord(userID, original, translations[0], language)
+	word.SetTranslations(translations)
+	if example := values[ImportColumnExample]; example != "" {
+		word.WithExample(example)
+	}
+
+	if pos := values[ImportColumnPartOfSpeech]; pos != "" {
+		partOfSpeech, ok := domain.ParsePartOfSpeech(pos)
+		if !ok {
+			return nil, ImportErrorPartOfSpeech
+		}
+		word.WithPartOfSpeech(partOfSpeech)
+	}
+
+	row := &ImportRow{Word: word}
+	if tags := splitImportList(values[ImportColumnTags]); len(tags) > 0 {
+		if len([]rune(tags[0])) > domain.MaxDeckNameLength {
+			return nil, ImportErrorDeck
+		}
+		row.Deck = tags[0]
+	}
+
+	return row, ""
+}
+
+// containsDeckName сравнивает имена колод без учёта регистра, как Deck.HasName
+func containsDeckName(names []string, name string) bool {
+	for _, existing := range names {
+		if strings.EqualFold(existing, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// usedImportColumns перечисляет распознанные колонки; без заголовка - столько,
+// сколько ячеек в самой длинной строке
+func usedImportColumns(columns []string, hasHeader bool, width int) []string {
+	if !hasHeader {
+		return columns[:min(width, len(columns))]
+	}
+
+	var used []string
+	for _, column := range columns {
+		if column != "" {
+			used = append(used, column)
+		}
+	}
+
+	return used
+}
+
+func splitImportList(text string) []string {
+	var items []string
+	for _, part := range strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ';' }) {
+		if part = strings.TrimSpace(part); part != "" {
+			items = append(items, part)
+		}
+	}
+
+	return items
+}
+
+func isEmptyRecord(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"ivanSaichkin/language-bot/internal/domain"
+	"ivanSaichkin/language-bot/internal/repository"
+)
+
+// fakeImportUsers - пользователь без изучаемого языка, импорт берёт английский
+type fakeImportUsers struct {
+	repository.UserRepository
+}
+
+func (fakeImportUsers) GetByID(ctx context.Context, userID int64) (*domain.User, error) {
+	return nil, nil
+}
+
+// fakeImportWords запоминает добавленные слова и не находит повторов
+type fakeImportWords struct {
+	WordService
+	added []*domain.Word
+}
+
+func (s *fakeImportWords) FindDuplicates(ctx context.Context, userID int64, words []*domain.Word) ([]*DuplicateMatch, error) {
+	return nil, nil
+}
+
+func (s *fakeImportWords) AddWords(ctx context.Context, userID int64, words []*domain.Word, onDuplicate string) (*BulkAddResult, error) {
+	s.added = append(s.added, words...)
+	return &BulkAddResult{Added: words}, nil
+}
+
+// fakeImportDecks хранит колоды в памяти
+type fakeImportDecks struct {
+	DeckService
+	decks []*domain.Deck
+}
+
+func (s *fakeImportDecks) FindDeck(ctx context.Context, userID int64, name string) (*domain.Deck, error) {
+	for _, deck := range s.decks {
+		if deck.HasName(name) {
+			return deck, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *fakeImportDecks) CreateDeck(ctx context.Context, userID int64, name string) (*domain.Deck, error) {
+	deck := domain.NewDeck(userID, name)
+	deck.ID = len(s.decks) + 1
+	s.decks = append(s.decks, deck)
+	return deck, nil
+}
+
+func TestImportTagsToDecks(t *testing.T) {
+	words := &fakeImportWords{}
+	decks := &fakeImportDecks{}
+	decks.CreateDeck(context.Background(), ownerID, "Food")
+
+	service := NewImportService(words, decks, fakeImportUsers{})
+	ctx := context.Background()
+
+	data := "word,translation,tags\n" +
+		"apple,яблоко,food\n" +
+		"bread,хлеб,\"Food, kitchen\"\n" +
+		"run,бежать,verbs\n" +
+		"house,дом,\n"
+
+	preview, err := service.ParseTable(ctx, ownerID, []byte(data))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(preview.Decks) != 2 || preview.Decks[0] != "food" || preview.Decks[1] != "verbs" {
+		t.Fatalf("preview decks = %v, want [food verbs]", preview.Decks)
+	}
+
+	if _, err := service.Import(ctx, ownerID, preview, DuplicateSkip); err != nil {
+		t.Fatalf("import: %v", err)
+	}
+
+	if len(decks.decks) != 2 {
+		t.Fatalf("got %d decks, want existing Food and new verbs", len(decks.decks))
+	}
+
+	want := map[string]int{"apple": 1, "bread": 1, "run": 2, "house": 0}
+	for _, word := range words.added {
+		if word.DeckID != want[word.Original] {
+			t.Errorf("%s: deck %d, want %d", word.Original, word.DeckID, want[word.Original])
+		}
+	}
+}
@@ -12,28 +12,88 @@ type UserService interface {
 	SetUserState(ctx context.Context, userID int64, state string) error
 	GetUserState(ctx context.Context, userID int64) (string, error)
 	UpdateDailyGoal(ctx context.Context, userID int64, goal int) error
+	SetScheduler(ctx context.Context, userID int64, scheduler string, desiredRetention float64) error
+	SetTimezone(ctx context.Context, userID int64, timezone string) error
+	SetAnswerStrictness(ctx context.Context, userID int64, strictness string) error
+	SetCardDirections(ctx context.Context, userID int64, directions string) error
+	SetStudyLanguage(ctx context.Context, userID int64, language string) error
 	GetAllUsers(ctx context.Context) ([]*domain.User, error)
 }
 
 type WordService interface {
 	AddWord(ctx context.Context, word *domain.Word) error
+	AddWords(ctx context.Context, userID int64, words []*domain.Word, onDuplicate string) (*BulkAddResult, error)
+	FindDuplicates(ctx context.Context, userID int64, words []*domain.Word) ([]*DuplicateMatch, error)
+	FindSimilarWords(ctx context.Context, userID int64, word *domain.Word) ([]*domain.Word, error)
 	GetUserWords(ctx context.Context, userID int64) ([]*domain.Word, error)
+	GetUserWord(ctx context.Context, userID int64, wordID int) (*domain.Word, error)
+	ListWords(ctx context.Context, userID int64, query WordListQuery) (*WordListPage, error)
+	GetWordDetails(ctx context.Context, userID int64, wordID int, historyLimit int) (*WordDetails, error)
+	SearchWords(ctx context.Context, userID int64, query string) ([]*domain.Word, error)
+	FindWords(ctx context.Context, userID int64, query string, limit int) ([]*WordMatch, error)
+	ResetWordProgress(ctx context.Context, userID int64, wordID int) (*domain.Word, error)
+	EditWord(ctx context.Context, userID int64, wordID int, field, value string) (*domain.Word, error)
+	DeleteUserWord(ctx context.Context, userID int64, wordID int) (*domain.Word, error)
 	GetDueWords(ctx context.Context, userID int64) ([]*domain.Word, error)
 	GetWordsForReview(ctx context.Context, userID int64, limit int) ([]*domain.Word, error)
-	UpdateWord(ctx context.Context, word *domain.Word) error
-	DeleteWord(ctx context.Context, wordID int) error
-	GetRandomTranslations(ctx context.Context, userID int64, exclude string, limit int) ([]string, error)
+	UpdateWord(ctx context.Context, userID int64, word *domain.Word) error
+	DeleteWord(ctx context.Context, userID int64, wordID int) error
+	GetRandomTranslations(ctx context.Context, word *domain.Word, limit int) ([]string, error)
 	GetWordProgress(ctx context.Context, userID int64) (*WordProgress, error)
+	GetLanguageProgress(ctx context.Context, userID int64) ([]*LanguageProgress, error)
+	CreateReverseCards(ctx context.Context, userID int64) (int, error)
+	CreateClozeCards(ctx context.Context, userID int64) (int, error)
+}
+
+type ImportService interface {
+	ParseTable(ctx context.Context, userID int64, data []byte) (*ImportPreview, error)
+	ParseAnki(data []byte) (*AnkiPackage, error)
+	PreviewAnki(ctx context.Context, userID int64, pkg *AnkiPackage, options AnkiImportOptions) (*ImportPreview, error)
+	Import(ctx context.Context, userID int64, preview *ImportPreview, onDuplicate string) (*ImportResult, error)
+}
+
+type ExportService interface {
+	Export(ctx context.Context, userID int64, format string) (*ExportFile, error)
+}
+
+type DeckService interface {
+	CreateDeck(ctx context.Context, userID int64, name string) (*domain.Deck, error)
+	GetUserDecks(ctx context.Context, userID int64) ([]*domain.Deck, error)
+	GetDeck(ctx context.Context, userID int64, deckID int) (*domain.Deck, error)
+	FindDeck(ctx context.Context, userID int64, name string) (*domain.Deck, error)
+	RenameDeck(ctx context.Context, deck *domain.Deck, name string) error
+	DeleteDeck(ctx context.Context, deck *domain.Deck) error
+	SetCurrentDeck(ctx context.Context, userID int64, deckID int) error
+	MoveWord(ctx context.Context, userID int64, original string, deckID int) (*domain.Word, error)
 }
 
 type ReviewService interface {
-	StartReviewSession(ctx context.Context, userID int64, limit int) (*domain.ReviewSession, error)
-	ProcessAnswer(ctx context.Context, session *domain.ReviewSession, answer string) (*ReviewAnswerResult, error)
+	StartReviewSession(ctx context.Context, userID int64, limit int, mode string, filter domain.WordFilter) (*domain.ReviewSession, error)
+	PrepareChoices(ctx context.Context, session *domain.ReviewSession) error
+	ProcessAnswer(ctx context.Context, session *domain.ReviewSession, answer string, grade domain.RecallGrade) (*ReviewAnswerResult, error)
+	RegradeLastAnswer(ctx context.Context, session *domain.ReviewSession, wordID int, grade domain.RecallGrade) (*ReviewAnswerResult, error)
 	CompleteReviewSession(ctx context.Context, session *domain.ReviewSession) error
 	GetSession(ctx context.Context, sessionID string) (*domain.ReviewSession, error)
 	CleanupOldSessions(ctx context.Context, olderThan time.Duration) (int, error)
 }
 
+type QuizService interface {
+	StartQuiz(ctx context.Context, userID int64, size int, filter domain.WordFilter) (*domain.Quiz, error)
+	AnswerQuestion(ctx context.Context, quiz *domain.Quiz, answer string) (*QuizAnswerResult, error)
+	ResetMissedWords(ctx context.Context, quiz *domain.Quiz) (int, error)
+}
+
+type SprintService interface {
+	StartSprint(ctx context.Context, userID int64) (*domain.Sprint, error)
+	AnswerPair(ctx context.Context, sprint *domain.Sprint, isMatch bool) (*SprintAnswerResult, error)
+	FinishSprint(ctx context.Context, sprint *domain.Sprint) (*SprintResult, error)
+}
+
+type MatchService interface {
+	StartMatch(ctx context.Context, userID int64) (*domain.MatchGame, error)
+	MatchPair(ctx context.Context, game *domain.MatchGame, position int) (*MatchPairResult, error)
+}
+
 type StatsService interface {
 	GetUserStats(ctx context.Context, userID int64) (*domain.UserStats, error)
 	AddReviewRecord(ctx context.Context, userID int64, isCorrect bool, duration time.Duration) error
@@ -44,6 +104,7 @@ type StatsService interface {
 
 type SpacedRepetitionService interface {
 	CalculateNextReview(word *domain.Word, isCorrect bool) (*domain.ReviewResult, error)
+	CalculateNextReviewWithQuality(word *domain.Word, quality int) (*domain.ReviewResult, error)
 	GetWordsForReview(words []*domain.Word) []*domain.Word
 	CalculateEaseFactor(word *domain.Word, quality int) float64
 }
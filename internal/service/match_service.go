@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"ivanSaichkin/language-bot/internal/constants"
+	"ivanSaichkin/language-bot/internal/domain"
+	"ivanSaichkin/language-bot/internal/repository"
+)
+
+const (
+	matchPairsCount = 5
+	MinMatchPairs   = 3
+)
+
+// matchService проводит игру «найди пары». Как и спринт, игра пишет ответы только в журнал:
+// расписание, дневная цель и статистика повторений не меняются.
+type matchService struct {
+	wordRepo repository.WordRepository
+	logRepo  repository.ReviewLogRepository
+}
+
+func NewMatchService(wordRepo repository.WordRepository, logRepo repository.ReviewLogRepository) MatchService {
+	return &matchService{
+		wordRepo: wordRepo,
+		logRepo:  logRepo,
+	}
+}
+
+func (s *matchService) StartMatch(ctx context.Context, userID int64) (*domain.MatchGame, error) {
+	// Берём с запасом: слова с одинаковыми переводами пропускаются
+	candidates, err := s.wordRepo.GetRandomWords(ctx, userID, domain.WordFilter{}, matchPairsCount*3)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get words for match: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var words []*domain.Word
+	for _, word := range candidates {
+		key := domain.NormalizeText(word.Translation)
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		words = append(words, word)
+		if len(words) == matchPairsCount {
+			break
+		}
+	}
+
+	if len(words) < MinMatchPairs {
+		return nil, fmt.Errorf("not enough words for match: %d", len(words))
+	}
+
+	log.Printf("🧩 Started match game for user %d with %d pairs", userID, len(words))
+	return domain.NewMatchGame(userID, words), nil
+}
+
+func (s *matchService) MatchPair(ctx context.Context, game *domain.MatchGame, position int) (*MatchPairResult, error) {
+	if !game.HasSelection() {
+		return nil, fmt.Errorf("no word selected")
+	}
+
+	original := game.Words[game.Selected].Original
+	isCorrect := game.Match(position)
+
+	if game.IsCompleted {
+		s.saveResults(ctx, game)
+	}
+
+	return &MatchPairResult{
+		IsCorrect:  isCorrect,
+		Original:   original,
+		IsComplete: game.IsCompleted,
+	}, nil
+}
+
+// saveResults записывает каждое слово в журнал: без ошибок - верный ответ
+func (s *matchService) saveResults(ctx context.Context, game *domain.MatchGame) {
+	perWord := game.GetDuration() / time.Duration(len(game.Words))
+
+	for i, word := range game.Words {
+		isCorrect := game.Mistakes[i] == 0
+
+		entry := domain.NewGameLog(word, constants.GameModeMatch, word.Translation, isCorrect, perWord)
+		if err := s.logRepo.Create(ctx, entry); err != nil {
+			log.Printf("⚠️ Failed to write match log: %v", err)
+		}
+	}
+
+	log.Printf("🏁 Match game finished for user %d with %d mistakes", game.UserID, game.TotalMistakes())
+}
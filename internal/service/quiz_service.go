@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+
+	"ivanSaichkin/language-bot/internal/constants"
+	"ivanSaichkin/language-bot/internal/domain"
+	"ivanSaichkin/language-bot/internal/repository"
+)
+
+const (
+	defaultQuizSize = 10
+	maxQuizSize     = 30
+)
+
+// quizService проводит тесты: ответы проверяются так же, как в повторениях,
+// но расписание слов и статистика повторений не меняются
+type quizService struct {
+	wordRepo repository.WordRepository
+	userRepo repository.UserRepository
+	matcher  AnswerMatcher
+}
+
+func NewQuizService(
+	wordRepo repository.WordRepository,
+	userRepo repository.UserRepository,
+	matcher AnswerMatcher,
+) QuizService {
+	return &quizService{
+		wordRepo: wordRepo,
+		userRepo: userRepo,
+		matcher:  matcher,
+	}
+}
+
+// StartQuiz составляет тест из случайных слов, filter ограничивает колоду и язык
+func (s *quizService) StartQuiz(ctx context.Context, userID int64, size int, filter domain.WordFilter) (*domain.Quiz, error) {
+	if size <= 0 {
+		size = defaultQuizSize
+	}
+	if size > maxQuizSize {
+		size = maxQuizSize
+	}
+
+	words, err := s.wordRepo.GetRandomWords(ctx, userID, filter, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get words for quiz: %w", err)
+	}
+
+	if len(words) == 0 {
+		return nil, fmt.Errorf("no words available for quiz")
+	}
+
+	questions := make([]*domain.QuizQuestion, 0, len(words))
+	for _, word := range words {
+		question, err := s.newQuestion(ctx, word)
+		if err != nil {
+			return nil, err
+		}
+		questions = append(questions, question)
+	}
+
+	quiz := domain.NewQuiz(userID, questions)
+
+	log.Printf("🧪 Started quiz for user %d with %d questions", userID, len(questions))
+	return quiz, nil
+}
+
+// newQuestion выбирает тип вопроса случайно: перевод, обратный перевод или выбор из вариантов
+func (s *quizService) newQuestion(ctx context.Context, word *domain.Word) (*domain.QuizQuestion, error) {
+	switch rand.Intn(3) {
+	case 0:
+		return domain.NewQuizQuestion(word, true), nil
+	case 1:
+		question := domain.NewQuizQuestion(word, false)
+
+		choices, err := buildAnswerChoices(ctx, s.wordRepo, question.Word)
+		if err != nil {
+			return nil, err
+		}
+		question.Choices = choices
+
+		return question, nil
+	default:
+		return domain.NewQuizQuestion(word, false), nil
+	}
+}
+
+func (s *quizService) AnswerQuestion(ctx context.Context, quiz *domain.Quiz, answer string) (*QuizAnswerResult, error) {
+	question := quiz.CurrentQuestion()
+	if question == nil {
+		return nil, fmt.Errorf("no current question in quiz")
+	}
+
+	strictness := constants.StrictnessNormal
+	if question.HasChoices() {
+		strictness = constants.StrictnessStrict
+	} else if user, err := s.userRepo.GetByID(ctx, quiz.UserID); err == nil && user != nil && user.AnswerStrictness != "" {
+		strictness = user.AnswerStrictness
+	}
+
+	match, matched := s.matcher.MatchAny(answer, question.Word.AcceptedAnswers(), strictness)
+	isCorrect := match != MatchWrong
+
+	quiz.Answer(answer, isCorrect)
+
+	return &QuizAnswerResult{
+		IsCorrect:          isCorrect,
+		Match:              match,
+		MatchedTranslation: matched,
+		Prompt:             question.Word.Prompt(),
+		CorrectAnswer:      question.Word.DisplayAnswer(),
+		IsComplete:         quiz.IsCompleted,
+	}, nil
+}
+
+// ResetMissedWords возвращает слова с ошибками в очередь повторения
+func (s *quizService) ResetMissedWords(ctx context.Context, quiz *domain.Quiz) (int, error) {
+	reset := 0
+	seen := make(map[int]bool)
+
+	for _, question := range quiz.MissedQuestions() {
+		if seen[question.Word.ID] {
+			continue
+		}
+		seen[question.Word.ID] = true
+
+		word, err := s.wordRepo.GetByID(ctx, quiz.UserID, question.Word.ID)
+		// Слово могли удалить, пока шёл тест
+		if errors.Is(err, domain.ErrWordNotFound) {
+			continue
+		}
+		if err != nil {
+			return reset, fmt.Errorf("failed to get word: %w", err)
+		}
+
+		word.MakeDue()
+		if err := s.wordRepo.Update(ctx, quiz.UserID, word); err != nil {
+			return reset, fmt.Errorf("failed to update word: %w", err)
+		}
+		reset++
+	}
+
+	log.Printf("🔁 Reset %d missed quiz words for user %d", reset, quiz.UserID)
+	return reset, nil
+}
@@ -4,99 +4,307 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strings"
+	"math/rand"
+	"sort"
 	"time"
 
+	"ivanSaichkin/language-bot/internal/constants"
 	"ivanSaichkin/language-bot/internal/domain"
 	"ivanSaichkin/language-bot/internal/repository"
 )
 
+const (
+	// Правильный ответ дольше этого порога оценивается как "Трудно"
+	slowAnswerThreshold = 15 * time.Second
+	// Ограничение времени ответа: пользователь мог отойти от экрана
+	maxResponseTime = 5 * time.Minute
+	// Количество вариантов ответа в режиме выбора
+	choiceOptionsCount = 4
+)
+
 type reviewService struct {
 	wordRepo   repository.WordRepository
+	cardRepo   repository.CardRepository
 	statsRepo  repository.StatsRepository
+	userRepo   repository.UserRepository
+	logRepo    repository.ReviewLogRepository
 	repetition SpacedRepetitionService
+	matcher    AnswerMatcher
 }
 
 func NewReviewService(
 	wordRepo repository.WordRepository,
+	cardRepo repository.CardRepository,
 	statsRepo repository.StatsRepository,
+	userRepo repository.UserRepository,
+	logRepo repository.ReviewLogRepository,
 	repetition SpacedRepetitionService,
+	matcher AnswerMatcher,
 ) ReviewService {
 	return &reviewService{
 		wordRepo:   wordRepo,
+		cardRepo:   cardRepo,
 		statsRepo:  statsRepo,
+		userRepo:   userRepo,
+		logRepo:    logRepo,
 		repetition: repetition,
+		matcher:    matcher,
 	}
 }
 
-func (s *reviewService) StartReviewSession(ctx context.Context, userID int64, limit int) (*domain.ReviewSession, error) {
-	words, err := s.wordRepo.GetWordsForReview(ctx, userID, limit)
+// StartReviewSession начинает сессию по словам, подходящим под filter (колода, язык)
+func (s *reviewService) StartReviewSession(ctx context.Context, userID int64, limit int, mode string, filter domain.WordFilter) (*domain.ReviewSession, error) {
+	var words []*domain.Word
+	var cardType string
+	var err error
+
+	switch mode {
+	case constants.ReviewModeCloze:
+		// В режиме упражнений с пропусками повторяются только такие карточки
+		cardType = constants.CardTypeCloze
+	case constants.ReviewModeChoice:
+	default:
+		mode = constants.ReviewModeTyping
+	}
+
+	if cardType == "" {
+		words, err = s.wordRepo.GetWordsForReview(ctx, userID, filter, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get words for review: %w", err)
+		}
+	}
+
+	cards, err := s.cardRepo.GetCardsForReview(ctx, userID, cardType, filter, limit)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get words for review: %w", err)
+		return nil, fmt.Errorf("failed to get cards for review: %w", err)
 	}
 
+	words = mixReviewCards(words, cards, limit)
+
 	if len(words) == 0 {
 		return nil, fmt.Errorf("no words available for review")
 	}
 
-	session := domain.NewReviewSession(userID, words)
+	session := domain.NewReviewSession(userID, words, mode)
 
-	log.Printf("🔄 Started review session for user %d with %d words", userID, len(words))
+	log.Printf("🔄 Started %s review session for user %d with %d words", mode, userID, len(words))
 	return session, nil
 }
 
-func (s *reviewService) ProcessAnswer(ctx context.Context, session *domain.ReviewSession, answer string) (*ReviewAnswerResult, error) {
+// PrepareChoices подбирает варианты ответа для текущего вопроса в режиме выбора.
+// Если других слов для вариантов нет, вопрос задаётся обычным вводом.
+func (s *reviewService) PrepareChoices(ctx context.Context, session *domain.ReviewSession) error {
+	session.Choices = nil
+
+	currentWord := session.GetCurrentWord()
+	if !session.IsChoiceMode() || currentWord == nil {
+		return nil
+	}
+
+	choices, err := buildAnswerChoices(ctx, s.wordRepo, currentWord)
+	if err != nil {
+		return err
+	}
+
+	session.Choices = choices
+	return nil
+}
+
+// buildAnswerChoices подбирает перемешанные варианты ответа вместе с правильным.
+// Возвращает nil, если других слов для вариантов нет.
+func buildAnswerChoices(ctx context.Context, wordRepo repository.WordRepository, word *domain.Word) ([]string, error) {
+	// Берём с запасом: часть вариантов может совпасть с правильным ответом
+	var candidates []string
+	var err error
+	if word.IsReverse() || word.IsCloze() {
+		candidates, err = wordRepo.GetRandomOriginals(ctx, word, choiceOptionsCount*3)
+	} else {
+		candidates, err = wordRepo.GetRandomTranslations(ctx, word, choiceOptionsCount*3)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get answer choices: %w", err)
+	}
+
+	correct := word.AcceptedAnswers()[0]
+
+	seen := make(map[string]bool)
+	for _, answer := range word.AcceptedAnswers() {
+		seen[domain.NormalizeText(answer)] = true
+	}
+
+	choices := []string{correct}
+	for _, candidate := range candidates {
+		key := domain.NormalizeText(candidate)
+		if key == "" || seen[key] {
+			continue
+		}
+
+		seen[key] = true
+		choices = append(choices, candidate)
+		if len(choices) == choiceOptionsCount {
+			break
+		}
+	}
+
+	if len(choices) < 2 {
+		return nil, nil
+	}
+
+	rand.Shuffle(len(choices), func(i, j int) {
+		choices[i], choices[j] = choices[j], choices[i]
+	})
+
+	return choices, nil
+}
+
+func (s *reviewService) ProcessAnswer(ctx context.Context, session *domain.ReviewSession, answer string, grade domain.RecallGrade) (*ReviewAnswerResult, error) {
 	currentWord := session.GetCurrentWord()
 	if currentWord == nil {
 		return nil, fmt.Errorf("no current word in session")
 	}
 
-	originalWord := currentWord.Original
-	correctTranslation := currentWord.Translation
+	originalWord := currentWord.Prompt()
+	correctTranslation := currentWord.DisplayAnswer()
 
-	isCorrect := strings.EqualFold(strings.TrimSpace(answer), correctTranslation)
+	user := s.getUser(ctx, session.UserID)
 
-	result, err := s.repetition.CalculateNextReview(currentWord, isCorrect)
+	// Вариант ответа выбирается кнопкой, поэтому опечатки не прощаем:
+	// иначе похожий неверный вариант засчитался бы как верный
+	isChoice := session.HasChoices()
+	strictness := s.strictnessFor(user)
+	if isChoice {
+		strictness = constants.StrictnessStrict
+	}
+
+	// Засчитываем любой из допустимых переводов
+	match, matchedTranslation := s.matcher.MatchAny(answer, currentWord.AcceptedAnswers(), strictness)
+	responseTime := s.getResponseTime(session)
+
+	// Если оценка не выбрана пользователем, выставляем её по ответу
+	if grade == domain.GradeAuto {
+		grade = s.autoGrade(match, responseTime)
+	} else if !grade.IsValid() {
+		return nil, fmt.Errorf("invalid recall grade: %d", grade)
+	}
+	grade = capChoiceGrade(grade, isChoice)
+
+	previous := *currentWord
+
+	scheduler := s.schedulerFor(user)
+	result, err := scheduler.CalculateNextReviewWithQuality(currentWord, grade.Quality())
 	if err != nil {
 		return nil, fmt.Errorf("failed to calculate next review: %w", err)
 	}
 
 	currentWord.MarkReviewedWithResult(result, time.Now().Add(result.NextInterval))
-	if err := s.wordRepo.Update(ctx, currentWord); err != nil {
+	if err := s.saveReviewedWord(ctx, session.UserID, currentWord); err != nil {
 		return nil, fmt.Errorf("failed to update word: %w", err)
 	}
 
-	startTime := session.StartTime
-	session.Answer(isCorrect)
+	session.LastAnswer = &domain.AnsweredWord{
+		WordID:    currentWord.ID,
+		Index:     session.CurrentIndex,
+		Previous:  previous,
+		Grade:     grade,
+		IsCorrect: result.IsCorrect,
+		Choice:    isChoice,
+	}
+
+	session.Answer(result.IsCorrect)
 
-	duration := time.Since(startTime)
-	if err := s.statsRepo.AddReview(ctx, session.UserID, isCorrect, duration); err != nil {
+	if err := s.statsRepo.AddReview(ctx, session.UserID, result.IsCorrect, responseTime); err != nil {
 		log.Printf("⚠️ Failed to record review stats: %v", err)
 	}
 
-	current, total := session.GetProgress()
-	progress := &SessionProgress{
-		Current:    current,
-		Total:      total,
-		Correct:    session.CorrectAnswers,
-		Accuracy:   session.GetAccuracy(),
-		IsComplete: session.IsCompleted,
+	entry := domain.NewReviewLog(&previous, answer, result, responseTime)
+	if isChoice {
+		entry.Mode = constants.ReviewModeChoice
+	}
+	if err := s.logRepo.Create(ctx, entry); err != nil {
+		log.Printf("⚠️ Failed to write review log: %v", err)
+	} else {
+		session.LastAnswer.LogID = entry.ID
 	}
 
 	reviewResult := &ReviewAnswerResult{
-		IsCorrect:       isCorrect,
-		CorrectAnswer:   correctTranslation,
-		OriginalWord:    originalWord,
-		NextInterval:    result.NextInterval,
-		SessionProgress: progress,
+		WordID:             currentWord.ID,
+		IsCorrect:          result.IsCorrect,
+		Grade:              grade,
+		Quality:            result.Quality,
+		Match:              match,
+		MatchedTranslation: matchedTranslation,
+		CorrectAnswer:      correctTranslation,
+		OriginalWord:       originalWord,
+		NextInterval:       result.NextInterval,
+		ResponseTime:       responseTime,
+		SessionProgress:    s.getSessionProgress(session),
 	}
 
-	log.Printf("📝 User %d answered: %s -> '%s' (correct: '%s', isCorrect: %v)",
-		session.UserID, originalWord, answer, correctTranslation, isCorrect)
+	log.Printf("📝 User %d answered: %s -> '%s' (correct: '%s', isCorrect: %v, quality: %d, time: %v)",
+		session.UserID, originalWord, answer, correctTranslation, result.IsCorrect, result.Quality, responseTime)
 
 	return reviewResult, nil
 }
 
+func (s *reviewService) RegradeLastAnswer(ctx context.Context, session *domain.ReviewSession, wordID int, grade domain.RecallGrade) (*ReviewAnswerResult, error) {
+	if !grade.IsValid() {
+		return nil, fmt.Errorf("invalid recall grade: %d", grade)
+	}
+
+	last := session.LastAnswer
+	if last == nil || last.WordID != wordID || last.Index >= len(session.Words) {
+		return nil, fmt.Errorf("answer for word %d can no longer be regraded", wordID)
+	}
+	grade = capChoiceGrade(grade, last.Choice)
+
+	// Откатываем слово к состоянию до ответа и пересчитываем интервал
+	word := session.Words[last.Index]
+	*word = last.Previous
+
+	scheduler := s.schedulerFor(s.getUser(ctx, session.UserID))
+	result, err := scheduler.CalculateNextReviewWithQuality(word, grade.Quality())
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate next review: %w", err)
+	}
+
+	word.MarkReviewedWithResult(result, time.Now().Add(result.NextInterval))
+	if err := s.saveReviewedWord(ctx, session.UserID, word); err != nil {
+		return nil, fmt.Errorf("failed to update word: %w", err)
+	}
+
+	if result.IsCorrect != last.IsCorrect {
+		delta := 1
+		if !result.IsCorrect {
+			delta = -1
+		}
+		session.CorrectAnswers += delta
+
+		if err := s.adjustCorrectAnswers(ctx, session.UserID, delta); err != nil {
+			log.Printf("⚠️ Failed to adjust review stats: %v", err)
+		}
+	}
+
+	last.Grade = grade
+	last.IsCorrect = result.IsCorrect
+
+	if err := s.updateReviewLog(ctx, last.LogID, result); err != nil {
+		log.Printf("⚠️ Failed to update review log: %v", err)
+	}
+
+	log.Printf("✏️ User %d regraded %s: quality %d", session.UserID, word.Original, result.Quality)
+
+	return &ReviewAnswerResult{
+		WordID:          word.ID,
+		IsCorrect:       result.IsCorrect,
+		Grade:           grade,
+		Quality:         result.Quality,
+		CorrectAnswer:   word.DisplayAnswer(),
+		OriginalWord:    word.Prompt(),
+		NextInterval:    result.NextInterval,
+		SessionProgress: s.getSessionProgress(session),
+	}, nil
+}
+
 func (s *reviewService) CompleteReviewSession(ctx context.Context, session *domain.ReviewSession) error {
 	if !session.IsCompleted {
 		session.Complete()
@@ -118,3 +326,150 @@ func (s *reviewService) CleanupOldSessions(ctx context.Context, olderThan time.D
 	log.Printf("🧹 Would cleanup review sessions older than %v", olderThan)
 	return 0, nil
 }
+
+// saveReviewedWord сохраняет состояние повторения в слово или в его дополнительную карточку
+func (s *reviewService) saveReviewedWord(ctx context.Context, userID int64, word *domain.Word) error {
+	if word.CardID != 0 {
+		return s.cardRepo.Update(ctx, userID, domain.CardFromReview(word))
+	}
+
+	return s.wordRepo.Update(ctx, userID, word)
+}
+
+// mixReviewCards объединяет слова и дополнительные карточки в одну очередь по сроку повторения.
+// Обе стороны одного слова в одну сессию не попадают, чтобы одна не подсказывала другую.
+func mixReviewCards(words, cards []*domain.Word, limit int) []*domain.Word {
+	if limit <= 0 || limit > 50 {
+		limit = 10
+	}
+
+	all := append(append([]*domain.Word{}, words...), cards...)
+	sort.SliceStable(all, func(i, j int) bool {
+		return all[i].NextReview.Before(all[j].NextReview)
+	})
+
+	var mixed []*domain.Word
+	seen := make(map[int]bool)
+	for _, word := range all {
+		if seen[word.ID] {
+			continue
+		}
+
+		seen[word.ID] = true
+		mixed = append(mixed, word)
+		if len(mixed) == limit {
+			break
+		}
+	}
+
+	return mixed
+}
+
+// capChoiceGrade ограничивает оценку ответа, выбранного из вариантов: узнать слово
+// среди нескольких проще, чем вспомнить самому, поэтому такой ответ не выше "Трудно"
+func capChoiceGrade(grade domain.RecallGrade, isChoice bool) domain.RecallGrade {
+	if isChoice && grade > domain.GradeHard {
+		return domain.GradeHard
+	}
+
+	return grade
+}
+
+// getUser возвращает настройки пользователя; при ошибке используются значения по умолчанию
+func (s *reviewService) getUser(ctx context.Context, userID int64) *domain.User {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		log.Printf("⚠️ Failed to get user settings, falling back to defaults: %v", err)
+		return nil
+	}
+
+	return user
+}
+
+// schedulerFor возвращает планировщик, выбранный пользователем
+func (s *reviewService) schedulerFor(user *domain.User) SpacedRepetitionService {
+	if user != nil && user.Scheduler == constants.SchedulerFSRS {
+		return NewFSRSService(user.DesiredRetention)
+	}
+
+	return s.repetition
+}
+
+func (s *reviewService) strictnessFor(user *domain.User) string {
+	if user == nil || user.AnswerStrictness == "" {
+		return constants.StrictnessNormal
+	}
+
+	return user.AnswerStrictness
+}
+
+// autoGrade оценивает ответ по правильности и времени:
+// ответ с опечаткой или долгий правильный ответ - "Трудно"
+func (s *reviewService) autoGrade(match MatchResult, responseTime time.Duration) domain.RecallGrade {
+	switch {
+	case match == MatchWrong:
+		return domain.GradeAgain
+	case match == MatchClose:
+		return domain.GradeHard
+	case responseTime > slowAnswerThreshold:
+		return domain.GradeHard
+	default:
+		return domain.GradeGood
+	}
+}
+
+func (s *reviewService) getResponseTime(session *domain.ReviewSession) time.Duration {
+	responseTime := session.ResponseTime()
+	if responseTime > maxResponseTime {
+		return maxResponseTime
+	}
+
+	return responseTime
+}
+
+func (s *reviewService) getSessionProgress(session *domain.ReviewSession) *SessionProgress {
+	current, total := session.GetProgress()
+	return &SessionProgress{
+		Current:    current,
+		Total:      total,
+		Correct:    session.CorrectAnswers,
+		Accuracy:   session.GetAccuracy(),
+		IsComplete: session.IsCompleted,
+	}
+}
+
+func (s *reviewService) updateReviewLog(ctx context.Context, logID int64, result *domain.ReviewResult) error {
+	if logID == 0 {
+		return nil
+	}
+
+	entry, err := s.logRepo.GetByID(ctx, logID)
+	if err != nil {
+		return err
+	}
+
+	if entry == nil {
+		return fmt.Errorf("review log %d not found", logID)
+	}
+
+	entry.ApplyResult(result)
+	return s.logRepo.Update(ctx, entry)
+}
+
+func (s *reviewService) adjustCorrectAnswers(ctx context.Context, userID int64, delta int) error {
+	stats, err := s.statsRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if stats == nil {
+		return nil
+	}
+
+	stats.TotalCorrect += delta
+	if stats.TotalCorrect < 0 {
+		stats.TotalCorrect = 0
+	}
+
+	return s.statsRepo.Update(ctx, stats)
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"fmt"
 	"ivanSaichkin/language-bot/internal/domain"
 	"math"
 	"time"
@@ -17,7 +18,20 @@ func NewSpacedRepetitionService() SpacedRepetitionService {
 }
 
 func (s *spacedRepetitionService) CalculateNextReview(word *domain.Word, isCorrect bool) (*domain.ReviewResult, error) {
-	quality := s.calculateQuality(isCorrect, word.Difficulty)
+	grade := domain.GradeAgain
+	if isCorrect {
+		grade = domain.GradeGood
+	}
+
+	return s.CalculateNextReviewWithQuality(word, grade.Quality())
+}
+
+func (s *spacedRepetitionService) CalculateNextReviewWithQuality(word *domain.Word, quality int) (*domain.ReviewResult, error) {
+	if quality < 0 || quality > 5 {
+		return nil, fmt.Errorf("quality must be between 0 and 5, got %d", quality)
+	}
+
+	isCorrect := quality >= 3
 
 	result := &domain.ReviewResult{
 		WordID:    word.ID,
@@ -33,8 +47,8 @@ func (s *spacedRepetitionService) CalculateNextReview(word *domain.Word, isCorre
 		} else if word.ReviewCount == 1 {
 			result.NextInterval = 3 * s.minInterval
 		} else {
-			previousInterval := float64(s.getPreviousInterval(word))
-			result.NextInterval = time.Duration(previousInterval * newEaseFactor)
+			previousInterval := s.getPreviousInterval(word)
+			result.NextInterval = time.Duration(previousInterval * newEaseFactor * float64(s.minInterval))
 		}
 
 		result.NewDifficulty = newEaseFactor
@@ -68,25 +82,13 @@ func (s *spacedRepetitionService) CalculateEaseFactor(word *domain.Word, quality
 	return easeFactor
 }
 
-func (s *spacedRepetitionService) calculateQuality(isCorrect bool, difficulty float64) int {
-	if !isCorrect {
-		return 0
-	}
-
-	if difficulty < 2.0 {
-		return 5
-	} else if difficulty < 2.5 {
-		return 4
-	} else if difficulty < 3.0 {
-		return 3
-	} else if difficulty < 3.5 {
-		return 2
-	} else {
-		return 1
+// getPreviousInterval возвращает прошлый интервал в днях. Для слов, у которых
+// фактический интервал ещё не сохранён, используется приближённая таблица.
+func (s *spacedRepetitionService) getPreviousInterval(word *domain.Word) float64 {
+	if word.IntervalDays > 0 {
+		return word.IntervalDays
 	}
-}
 
-func (s *spacedRepetitionService) getPreviousInterval(word *domain.Word) float64 {
 	if word.ReviewCount <= 1 {
 		return 1.0
 	}
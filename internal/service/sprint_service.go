@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	"ivanSaichkin/language-bot/internal/constants"
+	"ivanSaichkin/language-bot/internal/domain"
+	"ivanSaichkin/language-bot/internal/repository"
+)
+
+const (
+	sprintWordsLimit = 50
+	MinSprintWords   = 3
+)
+
+// sprintService проводит спринт. Ответы пишутся в журнал как слабый сигнал
+// о знании слова, но расписание повторений и дневная цель не меняются.
+type sprintService struct {
+	wordRepo  repository.WordRepository
+	statsRepo repository.StatsRepository
+	logRepo   repository.ReviewLogRepository
+}
+
+func NewSprintService(
+	wordRepo repository.WordRepository,
+	statsRepo repository.StatsRepository,
+	logRepo repository.ReviewLogRepository,
+) SprintService {
+	return &sprintService{
+		wordRepo:  wordRepo,
+		statsRepo: statsRepo,
+		logRepo:   logRepo,
+	}
+}
+
+func (s *sprintService) StartSprint(ctx context.Context, userID int64) (*domain.Sprint, error) {
+	words, err := s.wordRepo.GetRandomWords(ctx, userID, domain.WordFilter{}, sprintWordsLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get words for sprint: %w", err)
+	}
+
+	if len(words) < MinSprintWords {
+		return nil, fmt.Errorf("not enough words for sprint: %d", len(words))
+	}
+
+	sprint := domain.NewSprint(userID, words)
+	if err := s.nextPair(ctx, sprint); err != nil {
+		return nil, err
+	}
+
+	log.Printf("🏃 Started sprint for user %d with %d words", userID, len(words))
+	return sprint, nil
+}
+
+// nextPair показывает следующее слово с верным переводом или с переводом другого слова
+func (s *sprintService) nextPair(ctx context.Context, sprint *domain.Sprint) error {
+	word := sprint.NextWord()
+	accepted := word.AcceptedTranslations()
+
+	if rand.Intn(2) == 0 {
+		if wrong, err := s.wrongTranslation(ctx, word); err != nil {
+			return err
+		} else if wrong != "" {
+			sprint.ShowPair(word, wrong, false)
+			return nil
+		}
+	}
+
+	sprint.ShowPair(word, accepted[rand.Intn(len(accepted))], true)
+	return nil
+}
+
+// wrongTranslation выбирает перевод другого слова, не совпадающий ни с одним из верных
+func (s *sprintService) wrongTranslation(ctx context.Context, word *domain.Word) (string, error) {
+	candidates, err := s.wordRepo.GetRandomTranslations(ctx, word, 5)
+	if err != nil {
+		return "", fmt.Errorf("failed to get sprint translations: %w", err)
+	}
+
+	accepted := make(map[string]bool)
+	for _, translation := range word.AcceptedTranslations() {
+		accepted[domain.NormalizeText(translation)] = true
+	}
+
+	for _, candidate := range candidates {
+		if key := domain.NormalizeText(candidate); key != "" && !accepted[key] {
+			return candidate, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (s *sprintService) AnswerPair(ctx context.Context, sprint *domain.Sprint, isMatch bool) (*SprintAnswerResult, error) {
+	pair := sprint.Pair
+	if pair == nil || sprint.IsCompleted {
+		return nil, fmt.Errorf("sprint is already completed")
+	}
+
+	// Ответ после окончания минуты не засчитывается
+	if sprint.IsExpired() {
+		sprint.Complete()
+		return &SprintAnswerResult{IsComplete: true}, nil
+	}
+
+	isCorrect, points := sprint.Answer(isMatch)
+
+	answer := "false"
+	if isMatch {
+		answer = "true"
+	}
+	entry := domain.NewGameLog(pair.Word, constants.GameModeSprint, answer, isCorrect, time.Since(pair.ShownAt))
+	if err := s.logRepo.Create(ctx, entry); err != nil {
+		log.Printf("⚠️ Failed to write sprint log: %v", err)
+	}
+
+	if err := s.nextPair(ctx, sprint); err != nil {
+		return nil, err
+	}
+
+	return &SprintAnswerResult{
+		IsCorrect:   isCorrect,
+		Points:      points,
+		Original:    pair.Word.Original,
+		Translation: pair.Translation,
+		IsMatch:     pair.IsMatch,
+	}, nil
+}
+
+// FinishSprint завершает спринт и обновляет личный рекорд
+func (s *sprintService) FinishSprint(ctx context.Context, sprint *domain.Sprint) (*SprintResult, error) {
+	if !sprint.IsCompleted {
+		sprint.Complete()
+	}
+
+	stats, err := s.statsRepo.GetByUserID(ctx, sprint.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user stats: %w", err)
+	}
+
+	if stats == nil {
+		stats = domain.NewUserStats(sprint.UserID)
+		if err := s.statsRepo.Create(ctx, stats); err != nil {
+			return nil, fmt.Errorf("failed to create user stats: %w", err)
+		}
+	}
+
+	isNewBest := stats.RecordSprintScore(sprint.Score)
+	if isNewBest {
+		if err := s.statsRepo.Update(ctx, stats); err != nil {
+			return nil, fmt.Errorf("failed to update sprint best: %w", err)
+		}
+	}
+
+	log.Printf("🏁 Sprint finished for user %d: score %d (best %d)", sprint.UserID, sprint.Score, stats.SprintBest)
+
+	return &SprintResult{
+		Score:     sprint.Score,
+		Best:      stats.SprintBest,
+		IsNewBest: isNewBest,
+		Correct:   sprint.Correct,
+		Answered:  sprint.Answered,
+		MaxCombo:  sprint.MaxCombo,
+		Accuracy:  sprint.GetAccuracy(),
+	}, nil
+}
@@ -15,17 +15,20 @@ type statsService struct {
 	userRepo  repository.UserRepository
 	wordRepo  repository.WordRepository
 	statsRepo repository.StatsRepository
+	logRepo   repository.ReviewLogRepository
 }
 
 func NewStatsService(
 	userRepo repository.UserRepository,
 	wordRepo repository.WordRepository,
 	statsRepo repository.StatsRepository,
+	logRepo repository.ReviewLogRepository,
 ) StatsService {
 	return &statsService{
 		userRepo:  userRepo,
 		wordRepo:  wordRepo,
 		statsRepo: statsRepo,
+		logRepo:   logRepo,
 	}
 }
 
@@ -131,7 +134,10 @@ func (s *statsService) GetDailyProgress(ctx context.Context, userID int64) (*Dai
 		return nil, fmt.Errorf("user not found: %d", userID)
 	}
 
-	todayReviewed := 0
+	todayReviewed, err := s.logRepo.CountSince(ctx, userID, user.StartOfDay(time.Now()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count today reviews: %w", err)
+	}
 
 	remaining := user.DailyGoal - todayReviewed
 	if remaining < 0 {
@@ -6,6 +6,7 @@ import (
 	"log"
 	"time"
 
+	"ivanSaichkin/language-bot/internal/constants"
 	"ivanSaichkin/language-bot/internal/domain"
 	"ivanSaichkin/language-bot/internal/repository"
 )
@@ -109,6 +110,123 @@ func (s *userService) UpdateDailyGoal(ctx context.Context, userID int64, goal in
 	return nil
 }
 
+func (s *userService) SetScheduler(ctx context.Context, userID int64, scheduler string, desiredRetention float64) error {
+	if scheduler != constants.SchedulerSM2 && scheduler != constants.SchedulerFSRS {
+		return fmt.Errorf("unknown scheduler: %s", scheduler)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user for scheduler update: %w", err)
+	}
+
+	if user == nil {
+		return fmt.Errorf("user not found: %d", userID)
+	}
+
+	user.SetScheduler(scheduler, desiredRetention)
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update scheduler: %w", err)
+	}
+
+	log.Printf("🧠 User %d scheduler set to %s (retention %.2f)", userID, user.Scheduler, user.DesiredRetention)
+	return nil
+}
+
+func (s *userService) SetTimezone(ctx context.Context, userID int64, timezone string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user for timezone update: %w", err)
+	}
+
+	if user == nil {
+		return fmt.Errorf("user not found: %d", userID)
+	}
+
+	if err := user.SetTimezone(timezone); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update timezone: %w", err)
+	}
+
+	log.Printf("🕒 User %d timezone set to %s", userID, timezone)
+	return nil
+}
+
+func (s *userService) SetAnswerStrictness(ctx context.Context, userID int64, strictness string) error {
+	switch strictness {
+	case constants.StrictnessStrict, constants.StrictnessNormal, constants.StrictnessLenient:
+	default:
+		return fmt.Errorf("unknown answer strictness: %s", strictness)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user for strictness update: %w", err)
+	}
+
+	if user == nil {
+		return fmt.Errorf("user not found: %d", userID)
+	}
+
+	user.SetAnswerStrictness(strictness)
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update answer strictness: %w", err)
+	}
+
+	log.Printf("🔤 User %d answer strictness set to %s", userID, strictness)
+	return nil
+}
+
+func (s *userService) SetCardDirections(ctx context.Context, userID int64, directions string) error {
+	switch directions {
+	case constants.CardDirectionsForward, constants.CardDirectionsBoth:
+	default:
+		return fmt.Errorf("unknown card directions: %s", directions)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user for card directions update: %w", err)
+	}
+
+	if user == nil {
+		return fmt.Errorf("user not found: %d", userID)
+	}
+
+	user.SetCardDirections(directions)
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update card directions: %w", err)
+	}
+
+	log.Printf("🔄 User %d card directions set to %s", userID, directions)
+	return nil
+}
+
+func (s *userService) SetStudyLanguage(ctx context.Context, userID int64, language string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user for study language update: %w", err)
+	}
+
+	if user == nil {
+		return fmt.Errorf("user not found: %d", userID)
+	}
+
+	if err := user.SetStudyLanguage(language); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update study language: %w", err)
+	}
+
+	log.Printf("🌍 User %d study language set to %s", userID, language)
+	return nil
+}
+
 func (s *userService) GetAllUsers(ctx context.Context) ([]*domain.User, error) {
 	users, err := s.userRepo.GetAll(ctx)
 	if err != nil {
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"ivanSaichkin/language-bot/internal/constants"
+	"ivanSaichkin/language-bot/internal/domain"
+)
+
+// Что делать с новым словом, если такое или очень похожее уже есть в словаре
+const (
+	DuplicateSkip  = "skip"  // не добавлять
+	DuplicateMerge = "merge" // добавить переводы и пример к существующему слову
+	DuplicateKeep  = "keep"  // сохранить оба слова
+)
+
+// FindDuplicates находит для новых слов уже добавленные слова того же языка с тем же оригиналом
+// или оригиналом, отличающимся на опечатку, при общем переводе. Слова без повторов в результат не попадают.
+func (s *wordService) FindDuplicates(ctx context.Context, userID int64, words []*domain.Word) ([]*DuplicateMatch, error) {
+	found, err := s.findDuplicates(ctx, userID, words)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*DuplicateMatch
+	for _, word := range words {
+		if match := found[word]; match != nil {
+			matches = append(matches, match)
+		}
+	}
+
+	return matches, nil
+}
+
+// FindSimilarWords находит слова, которые пишутся почти как word, но переводятся иначе,
+// например house и horse. Такие слова добавляются как обычно, пользователю о них только напоминают.
+func (s *wordService) FindSimilarWords(ctx context.Context, userID int64, word *domain.Word) ([]*domain.Word, error) {
+	if !allowsTypos(word) {
+		return nil, nil
+	}
+
+	candidates, err := s.wordRepo.GetByLanguage(ctx, userID, word.Language)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get similar words: %w", err)
+	}
+
+	return findSimilar(candidates, word), nil
+}
+
+// findDuplicates ищет повторы новых слов. Точные повторы оригинала находятся запросом по индексу,
+// а слова языка загружаются, только если какое-то слово без точного повтора может отличаться опечаткой.
+func (s *wordService) findDuplicates(ctx context.Context, userID int64, words []*domain.Word) (map[*domain.Word]*DuplicateMatch, error) {
+	keys := make(map[string][]string)
+	for _, word := range words {
+		if key := domain.SearchKey(word.Original); key != "" {
+			keys[word.Language] = append(keys[word.Language], key)
+		}
+	}
+
+	exact := make(map[string]*domain.Word)
+	loaded := make(map[int]*domain.Word)
+	for language, languageKeys := range keys {
+		found, err := s.wordRepo.FindByOriginal(ctx, userID, language, languageKeys)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find duplicates: %w", err)
+		}
+
+		for _, existing := range found {
+			loaded[existing.ID] = existing
+			key := duplicateKey(existing)
+			if exact[key] == nil {
+				exact[key] = existing
+			}
+		}
+	}
+
+	matches := make(map[*domain.Word]*DuplicateMatch)
+	candidates := make(map[string][]*domain.Word)
+	for _, word := range words {
+		if existing := exact[duplicateKey(word)]; existing != nil && existing.ID != word.ID {
+			matches[word] = &DuplicateMatch{Word: word, Existing: existing, Exact: true}
+			continue
+		}
+
+		if !allowsTypos(word) {
+			continue
+		}
+
+		languageWords, ok := candidates[word.Language]
+		if !ok {
+			var err error
+			if languageWords, err = s.wordRepo.GetByLanguage(ctx, userID, word.Language); err != nil {
+				return nil, fmt.Errorf("failed to find duplicates: %w", err)
+			}
+			// Одно слово словаря - один объект, иначе объединение с ним одного повтора затрёт другое
+			for i, candidate := range languageWords {
+				if existing := loaded[candidate.ID]; existing != nil {
+					languageWords[i] = existing
+				}
+			}
+			candidates[word.Language] = languageWords
+		}
+
+		if match := findDuplicate(languageWords, word); match != nil {
+			matches[word] = match
+		}
+	}
+
+	return matches, nil
+}
+
+// mergeWord дописывает к существующему слову переводы нового и его пример, если своего нет.
+// Прогресс повторений существующего слова сохраняется.
+func (s *wordService) mergeWord(ctx context.Context, userID int64, existing, word *domain.Word) error {
+	existing.SetTranslations(append(existing.AcceptedTranslations(), word.AcceptedTranslations()...))
+	if existing.Example == "" {
+		existing.Example = word.Example
+	}
+
+	return s.UpdateWord(ctx, userID, existing)
+}
+
+// findDuplicate возвращает слово из списка, которое повторяет новое: сначала точный повтор
+// оригинала, затем оригинал с наименьшим числом опечаток и общим переводом.
+// Слова, похожие только написанием (house/horse), повтором не считаются - их находит findSimilar.
+func findDuplicate(existing []*domain.Word, word *domain.Word) *DuplicateMatch {
+	key := domain.SearchKey(word.Original)
+	if key == "" {
+		return nil
+	}
+
+	matcher := NewAnswerMatcher()
+
+	var best *DuplicateMatch
+	bestDistance := 0
+	for _, candidate := range existing {
+		if candidate.Language != word.Language || candidate.ID == word.ID {
+			continue
+		}
+
+		candidateKey := domain.SearchKey(candidate.Original)
+		if candidateKey == key {
+			return &DuplicateMatch{Word: word, Existing: candidate, Exact: true}
+		}
+
+		distance, ok := typoDistance(matcher, key, candidateKey)
+		if !ok || !sharesTranslation(candidate, word) {
+			continue
+		}
+
+		if best == nil || distance < bestDistance {
+			best, bestDistance = &DuplicateMatch{Word: word, Existing: candidate}, distance
+		}
+	}
+
+	return best
+}
+
+// findSimilar возвращает слова того же языка, которые отличаются от нового опечаткой,
+// но переводятся иначе. Это подсказка пользователю, а не повтор.
+func findSimilar(existing []*domain.Word, word *domain.Word) []*domain.Word {
+	key := domain.SearchKey(word.Original)
+	if key == "" {
+		return nil
+	}
+
+	matcher := NewAnswerMatcher()
+
+	var similar []*domain.Word
+	for _, candidate := range existing {
+		if candidate.Language != word.Language || candidate.ID == word.ID {
+			continue
+		}
+
+		candidateKey := domain.SearchKey(candidate.Original)
+		if candidateKey == key {
+			continue
+		}
+
+		if _, ok := typoDistance(matcher, key, candidateKey); ok && !sharesTranslation(candidate, word) {
+			similar = append(similar, candidate)
+		}
+	}
+
+	return similar
+}
+
+// allowsTypos сообщает, что оригинал слова достаточно длинный, чтобы искать его с опечаткой
+func allowsTypos(word *domain.Word) bool {
+	return allowedTypos(len([]rune(domain.SearchKey(word.Original))), constants.StrictnessNormal) > 0
+}
+
+// typoDistance сообщает, отличаются ли ключи не больше чем на допустимое число опечаток
+func typoDistance(matcher AnswerMatcher, key, candidateKey string) (int, bool) {
+	allowed := allowedTypos(len([]rune(key)), constants.StrictnessNormal)
+	if allowed == 0 {
+		return 0, false
+	}
+
+	// Слова, длина которых отличается больше допустимого числа опечаток, сравнивать незачем
+	if diff := len([]rune(candidateKey)) - len([]rune(key)); diff > allowed || -diff > allowed {
+		return 0, false
+	}
+
+	distance := matcher.Distance(key, candidateKey)
+	return distance, distance <= allowed
+}
+
+// sharesTranslation проверяет, что у слов есть хотя бы один общий перевод
+func sharesTranslation(a, b *domain.Word) bool {
+	translations := make(map[string]bool)
+	for _, translation := range a.AcceptedTranslations() {
+		translations[domain.SearchKey(translation)] = true
+	}
+
+	for _, translation := range b.AcceptedTranslations() {
+		if key := domain.SearchKey(translation); key != "" && translations[key] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsWord(words []*domain.Word, word *domain.Word) bool {
+	for _, w := range words {
+		if w.ID == word.ID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// duplicateKey - ключ для поиска повторов внутри одного списка новых слов
+func duplicateKey(word *domain.Word) string {
+	return fmt.Sprintf("%s:%s", word.Language, domain.SearchKey(word.Original))
+}
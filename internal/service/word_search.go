@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"ivanSaichkin/language-bot/internal/constants"
+	"ivanSaichkin/language-bot/internal/domain"
+)
+
+// FindWords ищет слова пользователя по оригиналу, переводам и примеру.
+// Сначала идут совпадения по началу слов из индекса, затем - слова, похожие на запрос
+// с точностью до опечаток: только ради них словарь просматривается целиком.
+func (s *wordService) FindWords(ctx context.Context, userID int64, query string, limit int) ([]*WordMatch, error) {
+	needle := domain.SearchKey(query)
+	if needle == "" {
+		return nil, nil
+	}
+
+	if limit <= 0 {
+		limit = 10
+	}
+
+	indexed, err := s.wordRepo.Search(ctx, userID, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search words: %w", err)
+	}
+
+	found := make(map[int]bool)
+	var matches []*WordMatch
+	for _, word := range indexed {
+		found[word.ID] = true
+		matches = append(matches, &WordMatch{Word: word})
+	}
+
+	allowed := allowedTypos(len([]rune(needle)), constants.StrictnessNormal)
+	if len(matches) >= limit || allowed == 0 {
+		return matches, nil
+	}
+
+	words, err := s.GetUserWords(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	type fuzzyMatch struct {
+		word     *domain.Word
+		distance int
+	}
+
+	matcher := NewAnswerMatcher()
+
+	var fuzzy []fuzzyMatch
+	for _, word := range words {
+		if found[word.ID] {
+			continue
+		}
+
+		fields := append([]string{word.Original, word.Example}, word.AcceptedTranslations()...)
+
+		// Сравниваем запрос и с полем целиком, и с отдельными словами в нём
+		best := allowed + 1
+		for _, field := range fields {
+			key := domain.SearchKey(field)
+			if key == "" {
+				continue
+			}
+
+			for _, candidate := range append(strings.Fields(key), key) {
+				best = min(best, matcher.Distance(needle, candidate))
+			}
+		}
+
+		if best <= allowed {
+			fuzzy = append(fuzzy, fuzzyMatch{word: word, distance: best})
+		}
+	}
+
+	sort.SliceStable(fuzzy, func(i, j int) bool {
+		return fuzzy[i].distance < fuzzy[j].distance
+	})
+
+	for _, match := range fuzzy {
+		matches = append(matches, &WordMatch{Word: match.word, Fuzzy: true})
+	}
+
+	return matches[:min(len(matches), limit)], nil
+}
+
+// ResetWordProgress возвращает слово и все его карточки в состояние нового слова
+func (s *wordService) ResetWordProgress(ctx context.Context, userID int64, wordID int) (*domain.Word, error) {
+	word, err := s.GetUserWord(ctx, userID, wordID)
+	if err != nil {
+		return nil, err
+	}
+
+	word.ResetProgress()
+	if err := s.UpdateWord(ctx, userID, word); err != nil {
+		return nil, err
+	}
+
+	cards, err := s.cardRepo.GetByWordID(ctx, userID, wordID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word cards: %w", err)
+	}
+
+	for _, card := range cards {
+		card.ResetProgress()
+		if err := s.cardRepo.Update(ctx, userID, card); err != nil {
+			return nil, fmt.Errorf("failed to reset card %d: %w", card.ID, err)
+		}
+	}
+
+	log.Printf("🔄 User %d reset progress of word %d", userID, wordID)
+	return word, nil
+}
+
+// containsKey проверяет, что хотя бы одно из полей содержит нормализованный запрос
+func containsKey(fields []string, needle string) bool {
+	for _, field := range fields {
+		if strings.Contains(domain.SearchKey(field), needle) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -2,33 +2,72 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"sort"
+	"time"
 
+	"ivanSaichkin/language-bot/internal/constants"
 	"ivanSaichkin/language-bot/internal/domain"
 	"ivanSaichkin/language-bot/internal/repository"
 )
 
+// MaxBulkWords - сколько слов можно добавить одним сообщением
+const MaxBulkWords = 100
+
 type wordService struct {
 	wordRepo  repository.WordRepository
 	statsRepo repository.StatsRepository
+	userRepo  repository.UserRepository
+	logRepo   repository.ReviewLogRepository
+	cardRepo  repository.CardRepository
 }
 
 func NewWordService(
 	wordRepo repository.WordRepository,
 	statsRepo repository.StatsRepository,
+	userRepo repository.UserRepository,
+	logRepo repository.ReviewLogRepository,
+	cardRepo repository.CardRepository,
 ) WordService {
 	return &wordService{
 		wordRepo:  wordRepo,
 		statsRepo: statsRepo,
+		userRepo:  userRepo,
+		logRepo:   logRepo,
+		cardRepo:  cardRepo,
 	}
 }
 
+// AddWord добавляет одно слово. Если такое или очень похожее слово уже есть в словаре,
+// слово не сохраняется и возвращается ошибка с domain.ErrDuplicateWord: повторы
+// найдёт FindDuplicates, а сохранить слово по выбору пользователя можно через AddWords.
 func (s *wordService) AddWord(ctx context.Context, word *domain.Word) error {
 	if err := s.validateWord(word); err != nil {
 		return fmt.Errorf("word validation failed: %w", err)
 	}
 
+	matches, err := s.findDuplicates(ctx, word.UserID, []*domain.Word{word})
+	if err != nil {
+		return err
+	}
+
+	if match := matches[word]; match != nil {
+		return fmt.Errorf("%q is similar to word %d %q: %w",
+			word.Original, match.Existing.ID, match.Existing.Original, domain.ErrDuplicateWord)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, word.UserID)
+	if err != nil {
+		log.Printf("⚠️ Failed to get user settings: %v", err)
+	}
+
+	// Новое слово попадает в текущую колоду пользователя
+	if user != nil && word.DeckID == 0 {
+		word.DeckID = user.CurrentDeckID
+	}
+
 	if err := s.wordRepo.Create(ctx, word); err != nil {
 		return fmt.Errorf("failed to create word: %w", err)
 	}
@@ -37,12 +76,120 @@ func (s *wordService) AddWord(ctx context.Context, word *domain.Word) error {
 		log.Printf("⚠️ Failed to update word stats: %v", err)
 	}
 
+	s.createExtraCards(ctx, user, word)
+
 	log.Printf("✅ Added word: %s - %s for user %d",
 		word.Original, word.Translation, word.UserID)
 
 	return nil
 }
 
+// AddWords добавляет несколько слов одной транзакцией. Слова, не прошедшие проверку
+// или повторяющиеся в самом списке, пропускаются. Со словами, которые уже есть в словаре
+// или отличаются от них опечаткой, поступает по onDuplicate - одной из Duplicate*.
+func (s *wordService) AddWords(ctx context.Context, userID int64, words []*domain.Word, onDuplicate string) (*BulkAddResult, error) {
+	if len(words) > MaxBulkWords {
+		return nil, fmt.Errorf("too many words: %d, max %d", len(words), MaxBulkWords)
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		log.Printf("⚠️ Failed to get user settings: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	var unique []*domain.Word
+
+	result := &BulkAddResult{}
+	for _, word := range words {
+		if err := s.validateWord(word); err != nil {
+			result.Invalid = append(result.Invalid, word)
+			continue
+		}
+
+		key := duplicateKey(word)
+		if seen[key] {
+			result.Duplicates = append(result.Duplicates, word)
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, word)
+	}
+
+	matches, err := s.findDuplicates(ctx, userID, unique)
+	if err != nil {
+		return nil, err
+	}
+
+	var merges []*DuplicateMatch
+	for _, word := range unique {
+		if match := matches[word]; match != nil {
+			switch onDuplicate {
+			case DuplicateKeep:
+			case DuplicateMerge:
+				merges = append(merges, match)
+				continue
+			default:
+				result.Duplicates = append(result.Duplicates, word)
+				continue
+			}
+		}
+
+		if user != nil && word.DeckID == 0 {
+			word.DeckID = user.CurrentDeckID
+		}
+		result.Added = append(result.Added, word)
+	}
+
+	if err := s.wordRepo.CreateBatch(ctx, result.Added); err != nil {
+		return nil, fmt.Errorf("failed to create words: %w", err)
+	}
+
+	for _, match := range merges {
+		if err := s.mergeWord(ctx, userID, match.Existing, match.Word); err != nil {
+			log.Printf("⚠️ Failed to merge word %q into %d: %v", match.Word.Original, match.Existing.ID, err)
+			result.Duplicates = append(result.Duplicates, match.Word)
+			continue
+		}
+		if !containsWord(result.Merged, match.Existing) {
+			result.Merged = append(result.Merged, match.Existing)
+		}
+	}
+
+	if len(result.Added) == 0 {
+		return result, nil
+	}
+
+	if err := s.updateWordStats(ctx, userID); err != nil {
+		log.Printf("⚠️ Failed to update word stats: %v", err)
+	}
+
+	for _, word := range result.Added {
+		s.createExtraCards(ctx, user, word)
+	}
+
+	log.Printf("✅ Added %d words for user %d, merged %d, skipped %d duplicates",
+		len(result.Added), userID, len(result.Merged), len(result.Duplicates))
+
+	return result, nil
+}
+
+// createExtraCards создаёт для нового слова обратную карточку и карточку с пропуском, если они нужны
+func (s *wordService) createExtraCards(ctx context.Context, user *domain.User, word *domain.Word) {
+	if user != nil && user.WantsReverseCards() {
+		if err := s.cardRepo.Create(ctx, domain.NewReverseCard(word)); err != nil {
+			log.Printf("⚠️ Failed to create reverse card: %v", err)
+		}
+	}
+
+	// Карточка с пропуском появляется, только если слово нашлось в примере
+	if card := domain.NewClozeCard(word); card != nil {
+		if err := s.cardRepo.Create(ctx, card); err != nil {
+			log.Printf("⚠️ Failed to create cloze card: %v", err)
+		}
+	}
+}
+
 func (s *wordService) GetUserWords(ctx context.Context, userID int64) ([]*domain.Word, error) {
 	words, err := s.wordRepo.GetByUserID(ctx, userID)
 	if err != nil {
@@ -52,6 +199,138 @@ func (s *wordService) GetUserWords(ctx context.Context, userID int64) ([]*domain
 	return words, nil
 }
 
+// GetUserWord возвращает слово пользователя; для чужого или несуществующего слова -
+// ошибка с domain.ErrWordForbidden или domain.ErrWordNotFound
+func (s *wordService) GetUserWord(ctx context.Context, userID int64, wordID int) (*domain.Word, error) {
+	word, err := s.wordRepo.GetByID(ctx, userID, wordID)
+	if err != nil {
+		s.logForbidden(err)
+		return nil, fmt.Errorf("failed to get word: %w", err)
+	}
+
+	return word, nil
+}
+
+// ListWords фильтрует, сортирует и делит слова пользователя на страницы.
+// Номер страницы за пределами списка приводится к первой или последней странице.
+func (s *wordService) ListWords(ctx context.Context, userID int64, query WordListQuery) (*WordListPage, error) {
+	words, err := s.GetUserWords(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &WordListPage{Languages: wordLanguages(words)}
+
+	var matched []*domain.Word
+	for _, word := range domain.FilterWords(words, query.Filter) {
+		if word.IsDueForReview() {
+			page.Due++
+		}
+
+		if query.PartOfSpeech != "" && word.PartOfSpeech != query.PartOfSpeech {
+			continue
+		}
+		if !matchesWordStatus(word, query.Status) {
+			continue
+		}
+		matched = append(matched, word)
+	}
+
+	sortWords(matched, query.Sort)
+
+	if query.PageSize <= 0 {
+		query.PageSize = 10
+	}
+
+	page.Total = len(matched)
+	page.Pages = max(1, (len(matched)+query.PageSize-1)/query.PageSize)
+	page.Page = max(0, min(query.Page, page.Pages-1))
+
+	start := page.Page * query.PageSize
+	page.Words = matched[min(start, len(matched)):min(start+query.PageSize, len(matched))]
+
+	return page, nil
+}
+
+// GetWordDetails возвращает слово пользователя вместе с карточками и последними ответами
+func (s *wordService) GetWordDetails(ctx context.Context, userID int64, wordID int, historyLimit int) (*WordDetails, error) {
+	word, err := s.GetUserWord(ctx, userID, wordID)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := s.cardRepo.GetByWordID(ctx, userID, wordID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word cards: %w", err)
+	}
+
+	history, err := s.logRepo.GetByWordID(ctx, userID, wordID, historyLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word history: %w", err)
+	}
+
+	return &WordDetails{Word: word, Cards: cards, History: history}, nil
+}
+
+// SearchWords ищет слова пользователя по части слова или перевода без учёта регистра и диакритики
+func (s *wordService) SearchWords(ctx context.Context, userID int64, query string) ([]*domain.Word, error) {
+	words, err := s.GetUserWords(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	needle := domain.SearchKey(query)
+	if needle == "" {
+		return nil, nil
+	}
+
+	var found []*domain.Word
+	for _, word := range words {
+		if containsKey(append([]string{word.Original}, word.AcceptedTranslations()...), needle) {
+			found = append(found, word)
+		}
+	}
+
+	return found, nil
+}
+
+// EditWord меняет одно поле слова пользователя
+func (s *wordService) EditWord(ctx context.Context, userID int64, wordID int, field, value string) (*domain.Word, error) {
+	word, err := s.GetUserWord(ctx, userID, wordID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := word.SetField(field, value); err != nil {
+		return nil, fmt.Errorf("word validation failed: %w", err)
+	}
+
+	if err := s.validateWord(word); err != nil {
+		return nil, fmt.Errorf("word validation failed: %w", err)
+	}
+
+	if err := s.UpdateWord(ctx, userID, word); err != nil {
+		return nil, err
+	}
+
+	log.Printf("✏️ User %d changed %s of word %d", userID, field, wordID)
+	return word, nil
+}
+
+// DeleteUserWord удаляет слово пользователя и возвращает его
+func (s *wordService) DeleteUserWord(ctx context.Context, userID int64, wordID int) (*domain.Word, error) {
+	word, err := s.GetUserWord(ctx, userID, wordID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.DeleteWord(ctx, userID, wordID); err != nil {
+		return nil, err
+	}
+
+	return word, nil
+}
+
 func (s *wordService) GetDueWords(ctx context.Context, userID int64) ([]*domain.Word, error) {
 	words, err := s.wordRepo.GetDueWords(ctx, userID)
 	if err != nil {
@@ -66,7 +345,7 @@ func (s *wordService) GetWordsForReview(ctx context.Context, userID int64, limit
 		limit = 10
 	}
 
-	words, err := s.wordRepo.GetWordsForReview(ctx, userID, limit)
+	words, err := s.wordRepo.GetWordsForReview(ctx, userID, domain.WordFilter{}, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get words for review: %w", err)
 	}
@@ -74,25 +353,34 @@ func (s *wordService) GetWordsForReview(ctx context.Context, userID int64, limit
 	return words, nil
 }
 
-func (s *wordService) UpdateWord(ctx context.Context, word *domain.Word) error {
-	if err := s.wordRepo.Update(ctx, word); err != nil {
+func (s *wordService) UpdateWord(ctx context.Context, userID int64, word *domain.Word) error {
+	if err := s.wordRepo.Update(ctx, userID, word); err != nil {
+		s.logForbidden(err)
 		return fmt.Errorf("failed to update word: %w", err)
 	}
 
 	return nil
 }
 
-func (s *wordService) DeleteWord(ctx context.Context, wordID int) error {
-	if err := s.wordRepo.Delete(ctx, wordID); err != nil {
+func (s *wordService) DeleteWord(ctx context.Context, userID int64, wordID int) error {
+	if err := s.wordRepo.Delete(ctx, userID, wordID); err != nil {
+		s.logForbidden(err)
 		return fmt.Errorf("failed to delete word: %w", err)
 	}
 
-	log.Printf("🗑️ Deleted word: %d", wordID)
+	log.Printf("🗑️ Deleted word %d of user %d", wordID, userID)
 	return nil
 }
 
-func (s *wordService) GetRandomTranslations(ctx context.Context, userID int64, exclude string, limit int) ([]string, error) {
-	translations, err := s.wordRepo.GetRandomTranslations(ctx, userID, exclude, limit)
+// logForbidden отмечает в логе попытки обратиться к чужим словам
+func (s *wordService) logForbidden(err error) {
+	if errors.Is(err, domain.ErrWordForbidden) {
+		log.Printf("⚠️ Forbidden word access: %v", err)
+	}
+}
+
+func (s *wordService) GetRandomTranslations(ctx context.Context, word *domain.Word, limit int) ([]string, error) {
+	translations, err := s.wordRepo.GetRandomTranslations(ctx, word, limit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get random translations: %w", err)
 	}
@@ -123,15 +411,179 @@ func (s *wordService) GetWordProgress(ctx context.Context, userID int64) (*WordP
 		progress = 0
 	}
 
+	todayReviewed, err := s.countTodayReviews(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &WordProgress{
 		TotalWords:    len(words),
 		LearnedWords:  learnedCount,
 		DueWords:      len(dueWords),
 		Progress:      progress,
-		TodayReviewed: 0,
+		TodayReviewed: todayReviewed,
 	}, nil
 }
 
+// GetLanguageProgress считает слова по языкам в порядке domain.SupportedLanguages
+func (s *wordService) GetLanguageProgress(ctx context.Context, userID int64) ([]*LanguageProgress, error) {
+	words, err := s.GetUserWords(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byLanguage := make(map[string]*LanguageProgress)
+	for _, word := range words {
+		progress, exists := byLanguage[word.Language]
+		if !exists {
+			progress = &LanguageProgress{Language: word.Language}
+			byLanguage[word.Language] = progress
+		}
+
+		progress.TotalWords++
+		if word.IsLearned() {
+			progress.LearnedWords++
+		}
+		if word.IsDueForReview() {
+			progress.DueWords++
+		}
+	}
+
+	result := make([]*LanguageProgress, 0, len(byLanguage))
+	for _, language := range domain.SupportedLanguages {
+		if progress, exists := byLanguage[language.Code]; exists {
+			result = append(result, progress)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *wordService) countTodayReviews(ctx context.Context, userID int64) (int, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	since := time.Now()
+	if user != nil {
+		since = user.StartOfDay(since)
+	}
+
+	count, err := s.logRepo.CountSince(ctx, userID, since)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count today reviews: %w", err)
+	}
+
+	return count, nil
+}
+
+// CreateReverseCards создаёт обратные карточки для уже добавленных слов пользователя
+func (s *wordService) CreateReverseCards(ctx context.Context, userID int64) (int, error) {
+	created, err := s.cardRepo.CreateMissing(ctx, userID, constants.CardTypeReverse)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create reverse cards: %w", err)
+	}
+
+	log.Printf("🔄 Created %d reverse cards for user %d", created, userID)
+	return created, nil
+}
+
+// CreateClozeCards создаёт карточки с пропуском для слов с примерами, у которых их ещё нет
+func (s *wordService) CreateClozeCards(ctx context.Context, userID int64) (int, error) {
+	words, err := s.wordRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user words: %w", err)
+	}
+
+	// Найти слово в примере можно только в коде, поэтому уже созданные карточки
+	// загружаем одним запросом, а не проверяем каждое слово отдельно
+	withCloze, err := s.cardRepo.GetWordIDsWithCard(ctx, userID, constants.CardTypeCloze)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get cloze cards: %w", err)
+	}
+
+	created := 0
+	for _, word := range words {
+		if withCloze[word.ID] {
+			continue
+		}
+
+		card := domain.NewClozeCard(word)
+		if card == nil {
+			continue
+		}
+
+		if err := s.cardRepo.Create(ctx, card); err != nil {
+			return created, fmt.Errorf("failed to create cloze card: %w", err)
+		}
+		created++
+	}
+
+	if created > 0 {
+		log.Printf("🧩 Created %d cloze cards for user %d", created, userID)
+	}
+	return created, nil
+}
+
+func matchesWordStatus(word *domain.Word, status string) bool {
+	switch status {
+	case constants.WordStatusDue:
+		return word.IsDueForReview()
+	case constants.WordStatusLearning:
+		return !word.IsLearned()
+	case constants.WordStatusLearned:
+		return word.IsLearned()
+	default:
+		return true
+	}
+}
+
+// sortWords упорядочивает слова для списка; при равенстве - по алфавиту.
+// Самые слабые - с наименьшим прогрессом, среди них - с большим числом повторений.
+func sortWords(words []*domain.Word, order string) {
+	sort.SliceStable(words, func(i, j int) bool {
+		a, b := words[i], words[j]
+
+		switch order {
+		case constants.WordSortNewest:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+		case constants.WordSortDue:
+			if !a.NextReview.Equal(b.NextReview) {
+				return a.NextReview.Before(b.NextReview)
+			}
+		case constants.WordSortWeakest:
+			if a.GetProgress() != b.GetProgress() {
+				return a.GetProgress() < b.GetProgress()
+			}
+			if a.ReviewCount != b.ReviewCount {
+				return a.ReviewCount > b.ReviewCount
+			}
+		}
+
+		return domain.NormalizeText(a.Original) < domain.NormalizeText(b.Original)
+	})
+}
+
+// wordLanguages перечисляет языки слов в порядке domain.SupportedLanguages
+func wordLanguages(words []*domain.Word) []string {
+	used := make(map[string]bool)
+	for _, word := range words {
+		used[word.Language] = true
+	}
+
+	var languages []string
+	for _, language := range domain.SupportedLanguages {
+		if used[language.Code] {
+			languages = append(languages, language.Code)
+		}
+	}
+
+	return languages
+}
+
 func (s *wordService) validateWord(word *domain.Word) error {
 	if word.Original == "" {
 		return fmt.Errorf("original word cannot be empty")
@@ -145,12 +597,18 @@ func (s *wordService) validateWord(word *domain.Word) error {
 		return fmt.Errorf("user ID cannot be zero")
 	}
 
+	if !domain.IsSupportedLanguage(word.Language) {
+		return fmt.Errorf("unsupported language: %s", word.Language)
+	}
+
 	if len(word.Original) > 500 {
 		return fmt.Errorf("original word too long")
 	}
 
-	if len(word.Translation) > 500 {
-		return fmt.Errorf("translation too long")
+	for _, translation := range word.AcceptedTranslations() {
+		if len(translation) > 500 {
+			return fmt.Errorf("translation too long")
+		}
 	}
 
 	return nil
@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ivanSaichkin/language-bot/internal/constants"
+	"ivanSaichkin/language-bot/internal/domain"
+	"ivanSaichkin/language-bot/internal/repository"
+)
+
+const (
+	ownerID    int64 = 1
+	strangerID int64 = 2
+)
+
+// fakeWordRepository хранит слова в памяти и проверяет владельца так же, как SQLite-репозиторий.
+// Остальные методы интерфейса в этих тестах не вызываются.
+type fakeWordRepository struct {
+	repository.WordRepository
+	words   map[int]*domain.Word
+	updates int
+	deletes int
+	scans   int
+}
+
+func newFakeWordRepository(words ...*domain.Word) *fakeWordRepository {
+	repo := &fakeWordRepository{words: make(map[int]*domain.Word)}
+	for _, word := range words {
+		repo.words[word.ID] = word
+	}
+
+	return repo
+}
+
+func (r *fakeWordRepository) access(userID int64, wordID int) (*domain.Word, error) {
+	word, ok := r.words[wordID]
+	if !ok {
+		return nil, domain.ErrWordNotFound
+	}
+	if word.UserID != userID {
+		return nil, domain.ErrWordForbidden
+	}
+
+	return word, nil
+}
+
+func (r *fakeWordRepository) GetByID(ctx context.Context, userID int64, wordID int) (*domain.Word, error) {
+	word, err := r.access(userID, wordID)
+	if err != nil {
+		return nil, err
+	}
+
+	copied := *word
+	return &copied, nil
+}
+
+func (r *fakeWordRepository) Update(ctx context.Context, userID int64, word *domain.Word) error {
+	if _, err := r.access(userID, word.ID); err != nil {
+		return err
+	}
+
+	saved := *word
+	r.words[word.ID] = &saved
+	r.updates++
+	return nil
+}
+
+func (r *fakeWordRepository) Delete(ctx context.Context, userID int64, wordID int) error {
+	if _, err := r.access(userID, wordID); err != nil {
+		return err
+	}
+
+	delete(r.words, wordID)
+	r.deletes++
+	return nil
+}
+
+func (r *fakeWordRepository) FindByOriginal(ctx context.Context, userID int64, language string, keys []string) ([]*domain.Word, error) {
+	var words []*domain.Word
+	for _, word := range r.words {
+		for _, key := range keys {
+			if word.UserID == userID && word.Language == language && domain.SearchKey(word.Original) == key {
+				words = append(words, word)
+				break
+			}
+		}
+	}
+
+	return words, nil
+}
+
+func (r *fakeWordRepository) GetByLanguage(ctx context.Context, userID int64, language string) ([]*domain.Word, error) {
+	r.scans++
+
+	var words []*domain.Word
+	for _, word := range r.words {
+		if word.UserID == userID && word.Language == language {
+			words = append(words, word)
+		}
+	}
+
+	return words, nil
+}
+
+func newTestWordService() (WordService, *fakeWordRepository) {
+	word := domain.NewWord(ownerID, "house", "дом", constants.LanguageEnglish)
+	word.ID = 7
+
+	repo := newFakeWordRepository(word)
+	return NewWordService(repo, nil, nil, nil, nil), repo
+}
+
+func TestGetUserWord(t *testing.T) {
+	service, _ := newTestWordService()
+	ctx := context.Background()
+
+	word, err := service.GetUserWord(ctx, ownerID, 7)
+	if err != nil || word.Original != "house" {
+		t.Fatalf("owner: got %v, %v", word, err)
+	}
+
+	if _, err := service.GetUserWord(ctx, strangerID, 7); !errors.Is(err, domain.ErrWordForbidden) {
+		t.Errorf("stranger: got %v, want ErrWordForbidden", err)
+	}
+
+	if _, err := service.GetUserWord(ctx, ownerID, 8); !errors.Is(err, domain.ErrWordNotFound) {
+		t.Errorf("missing: got %v, want ErrWordNotFound", err)
+	}
+}
+
+func TestEditWordOwnership(t *testing.T) {
+	service, repo := newTestWordService()
+	ctx := context.Background()
+
+	if _, err := service.EditWord(ctx, strangerID, 7, constants.WordFieldOriginal, "stolen"); !errors.Is(err, domain.ErrWordForbidden) {
+		t.Fatalf("stranger: got %v, want ErrWordForbidden", err)
+	}
+	if repo.updates != 0 || repo.words[7].Original != "house" {
+		t.Fatalf("stranger changed the word: %q", repo.words[7].Original)
+	}
+
+	word, err := service.EditWord(ctx, ownerID, 7, constants.WordFieldTranslation, "дом, здание")
+	if err != nil {
+		t.Fatalf("owner: unexpected error: %v", err)
+	}
+	if got := repo.words[7].AcceptedTranslations(); len(got) != 2 || got[1] != "здание" {
+		t.Errorf("owner: saved translations %v", got)
+	}
+	if word.Translation != "дом" {
+		t.Errorf("owner: returned translation %q", word.Translation)
+	}
+}
+
+func TestEditWordRejectsInvalidValue(t *testing.T) {
+	service, repo := newTestWordService()
+	ctx := context.Background()
+
+	tests := []struct {
+		field string
+		value string
+	}{
+		{constants.WordFieldOriginal, " "},
+		{constants.WordFieldTranslation, ", ;"},
+		{constants.WordFieldPartOfSpeech, "pronoun"},
+		{constants.WordFieldLanguage, "xx"},
+		{"deck", "1"},
+	}
+
+	for _, tt := range tests {
+		if _, err := service.EditWord(ctx, ownerID, 7, tt.field, tt.value); err == nil {
+			t.Errorf("%s=%q: expected error", tt.field, tt.value)
+		}
+	}
+
+	if repo.updates != 0 {
+		t.Errorf("invalid values were saved %d times", repo.updates)
+	}
+}
+
+func TestDeleteUserWordOwnership(t *testing.T) {
+	service, repo := newTestWordService()
+	ctx := context.Background()
+
+	if _, err := service.DeleteUserWord(ctx, strangerID, 7); !errors.Is(err, domain.ErrWordForbidden) {
+		t.Fatalf("stranger: got %v, want ErrWordForbidden", err)
+	}
+	if err := service.DeleteWord(ctx, strangerID, 7); !errors.Is(err, domain.ErrWordForbidden) {
+		t.Fatalf("stranger DeleteWord: got %v, want ErrWordForbidden", err)
+	}
+	if repo.deletes != 0 {
+		t.Fatal("stranger deleted the word")
+	}
+
+	word, err := service.DeleteUserWord(ctx, ownerID, 7)
+	if err != nil || word.Original != "house" {
+		t.Fatalf("owner: got %v, %v", word, err)
+	}
+
+	if _, err := service.DeleteUserWord(ctx, ownerID, 7); !errors.Is(err, domain.ErrWordNotFound) {
+		t.Errorf("second delete: got %v, want ErrWordNotFound", err)
+	}
+}
+
+func TestUpdateWordOwnership(t *testing.T) {
+	service, repo := newTestWordService()
+	ctx := context.Background()
+
+	word := *repo.words[7]
+	word.MakeDue()
+	if err := service.UpdateWord(ctx, strangerID, &word); !errors.Is(err, domain.ErrWordForbidden) {
+		t.Fatalf("stranger: got %v, want ErrWordForbidden", err)
+	}
+
+	if err := service.UpdateWord(ctx, ownerID, &word); err != nil {
+		t.Fatalf("owner: unexpected error: %v", err)
+	}
+	if repo.updates != 1 {
+		t.Errorf("updates = %d, want 1", repo.updates)
+	}
+}
+
+func TestFindDuplicate(t *testing.T) {
+	hello := domain.NewWord(ownerID, "Hello", "привет", constants.LanguageEnglish)
+	hello.ID = 1
+	cafe := domain.NewWord(ownerID, "café", "кафе", constants.LanguageFrench)
+	cafe.ID = 2
+	house := domain.NewWord(ownerID, "house", "дом", constants.LanguageEnglish)
+	house.ID = 3
+	word := domain.NewWord(ownerID, "word", "слово", constants.LanguageEnglish)
+	word.ID = 4
+	existing := []*domain.Word{hello, cafe, house, word}
+
+	tests := []struct {
+		original    string
+		translation string
+		language    string
+		want        int
+		exact       bool
+		similar     int
+	}{
+		{"hello", "здравствуй", constants.LanguageEnglish, 1, true, 0},
+		{"hello!", "привет", constants.LanguageEnglish, 1, true, 0},
+		{"helo", "привет", constants.LanguageEnglish, 1, false, 0},
+		{"hlelo", "Привет", constants.LanguageEnglish, 1, false, 0},
+		{"help", "помощь", constants.LanguageEnglish, 0, false, 0},
+		{"hello", "привет", constants.LanguageFrench, 0, false, 0},
+		{"cafe", "кофейня", constants.LanguageFrench, 2, true, 0},
+		// Минимальные пары: пишутся почти одинаково, но это разные слова
+		{"horse", "лошадь", constants.LanguageEnglish, 0, false, 3},
+		{"hous", "дом", constants.LanguageEnglish, 3, false, 0},
+		{"work", "работа", constants.LanguageEnglish, 0, false, 4},
+		{"world", "мир", constants.LanguageEnglish, 0, false, 4},
+	}
+
+	for _, tt := range tests {
+		newWord := domain.NewWord(ownerID, tt.original, tt.translation, tt.language)
+
+		match := findDuplicate(existing, newWord)
+		if tt.want == 0 && match != nil {
+			t.Errorf("%s/%s: unexpected duplicate %q", tt.original, tt.language, match.Existing.Original)
+		}
+		if tt.want != 0 && (match == nil || match.Existing.ID != tt.want || match.Exact != tt.exact) {
+			t.Errorf("%s/%s: got %+v, want word %d exact=%v", tt.original, tt.language, match, tt.want, tt.exact)
+		}
+
+		similar := findSimilar(existing, newWord)
+		if tt.similar == 0 && len(similar) != 0 {
+			t.Errorf("%s/%s: unexpected similar word %q", tt.original, tt.language, similar[0].Original)
+		}
+		if tt.similar != 0 && (len(similar) != 1 || similar[0].ID != tt.similar) {
+			t.Errorf("%s/%s: similar %v, want word %d", tt.original, tt.language, similar, tt.similar)
+		}
+	}
+}
+
+func TestFindDuplicatesLoadsLanguageOnlyForTypos(t *testing.T) {
+	service, repo := newTestWordService()
+	ctx := context.Background()
+
+	tests := []struct {
+		original string
+		language string
+		exact    bool
+		found    bool
+		scans    int
+	}{
+		// Точный повтор находится по ключу, слова языка не загружаются
+		{"House!", constants.LanguageEnglish, true, true, 0},
+		{"hous", constants.LanguageEnglish, false, true, 1},
+		// Короткие слова опечаток не допускают
+		{"hat", constants.LanguageEnglish, false, false, 0},
+		{"house", constants.LanguageFrench, false, false, 1},
+	}
+
+	for _, tt := range tests {
+		repo.scans = 0
+		word := domain.NewWord(ownerID, tt.original, "дом", tt.language)
+
+		matches, err := service.FindDuplicates(ctx, ownerID, []*domain.Word{word})
+		if err != nil {
+			t.Fatalf("%s: find duplicates: %v", tt.original, err)
+		}
+
+		if tt.found != (len(matches) == 1) || (tt.found && (matches[0].Existing.ID != 7 || matches[0].Exact != tt.exact)) {
+			t.Errorf("%s/%s: got %+v, want found=%v exact=%v", tt.original, tt.language, matches, tt.found, tt.exact)
+		}
+		if repo.scans != tt.scans {
+			t.Errorf("%s/%s: loaded language %d times, want %d", tt.original, tt.language, repo.scans, tt.scans)
+		}
+	}
+}